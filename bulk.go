@@ -155,6 +155,12 @@ func (s *BulkService) EstimatedSizeInBytes() int64 {
 // bulkable request, i.e. BulkIndexRequest, BulkUpdateRequest, and
 // BulkDeleteRequest.
 func (s *BulkService) estimateSizeInBytes(r BulkableRequest) int64 {
+	return bulkableRequestSizeInBytes(r)
+}
+
+// bulkableRequestSizeInBytes returns the estimated size of the given
+// bulkable request in the bulk request body.
+func bulkableRequestSizeInBytes(r BulkableRequest) int64 {
 	lines, _ := r.Source()
 	size := 0
 	for _, line := range lines {
@@ -403,6 +409,30 @@ func (r *BulkResponse) Failed() []*BulkResponseItem {
 	return errors
 }
 
+// FailedByStatus returns those items of a bulk response that have errors,
+// grouped by their status code.
+func (r *BulkResponse) FailedByStatus() map[int][]*BulkResponseItem {
+	failed := make(map[int][]*BulkResponseItem)
+	for _, item := range r.Failed() {
+		failed[item.Status] = append(failed[item.Status], item)
+	}
+	return failed
+}
+
+// RetriableFailures returns those items of a bulk response that failed
+// temporarily and are worth retrying, i.e. those with a status code of
+// 429 (Too Many Requests) or 503 (Service Unavailable).
+func (r *BulkResponse) RetriableFailures() []*BulkResponseItem {
+	var failed []*BulkResponseItem
+	for _, item := range r.Failed() {
+		switch item.Status {
+		case 429, 503:
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
 // Succeeded returns those items of a bulk response that have no errors,
 // i.e. those have a status code between 200 and 299.
 func (r *BulkResponse) Succeeded() []*BulkResponseItem {
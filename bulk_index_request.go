@@ -136,7 +136,10 @@ func (r *BulkIndexRequest) VersionType(versionType string) *BulkIndexRequest {
 	return r
 }
 
-// Doc specifies the document to index.
+// Doc specifies the document to index. If doc is a json.RawMessage or a
+// string (or a pointer to either), it is used verbatim as the source line
+// without being marshaled again. Pre-serialized JSON must not contain
+// newlines.
 func (r *BulkIndexRequest) Doc(doc interface{}) *BulkIndexRequest {
 	r.doc = doc
 	r.source = nil
@@ -5,6 +5,7 @@
 package elastic
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -92,6 +93,26 @@ func TestBulkIndexRequestSerialization(t *testing.T) {
 
 var bulkIndexRequestSerializationResult string
 
+func TestBulkIndexRequestWithRawDoc(t *testing.T) {
+	// Keys are neither sorted nor compacted by marshaling
+	doc := json.RawMessage(`{"user":"olivere", "retweets":42,"message":"Welcome"}`)
+	s := NewBulkService(nil).
+		Add(NewBulkIndexRequest().Index("index1").Type("doc").Id("1").Doc(doc)).
+		Add(NewBulkIndexRequest().Index("index1").Type("doc").Id("2").Doc(&doc))
+	got, err := s.bodyAsString()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"index":{"_index":"index1","_id":"1","_type":"doc"}}
+{"user":"olivere", "retweets":42,"message":"Welcome"}
+{"index":{"_index":"index1","_id":"2","_type":"doc"}}
+{"user":"olivere", "retweets":42,"message":"Welcome"}
+`
+	if got != expected {
+		t.Errorf("expected\n%s\ngot:\n%s", expected, got)
+	}
+}
+
 func BenchmarkBulkIndexRequestSerialization(b *testing.B) {
 	b.Run("stdlib", func(b *testing.B) {
 		r := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").
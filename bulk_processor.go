@@ -7,6 +7,7 @@ package elastic
 import (
 	"context"
 	"errors"
+	"hash/fnv"
 	"net"
 	"sync"
 	"sync/atomic"
@@ -44,10 +45,15 @@ type BulkProcessorService struct {
 	c                    *Client
 	beforeFn             BulkBeforeFunc
 	afterFn              BulkAfterFunc
+	itemFailureFn        BulkItemFailureFunc
+	startFn              BulkStartFunc
+	stopFn               BulkStopFunc
 	name                 string        // name of processor
 	numWorkers           int           // # of workers (>= 1)
+	ordered              bool          // indicates whether requests on the same document go to the same worker
 	bulkActions          int           // # of requests after which to commit
 	bulkSize             int           // # of bytes after which to commit
+	maxInFlightBytes     int64         // # of bytes queued or in flight after which Add blocks
 	flushInterval        time.Duration // periodic flush interval
 	wantStats            bool          // indicates whether to gather statistics
 	backoff              Backoff       // a custom Backoff to use for errors
@@ -77,6 +83,21 @@ type BulkBeforeFunc func(executionId int64, requests []BulkableRequest)
 // after a commit to Elasticsearch. The err parameter signals an error.
 type BulkAfterFunc func(executionId int64, requests []BulkableRequest, response *BulkResponse, err error)
 
+// BulkItemFailureFunc defines the signature of callbacks that are executed
+// for every failed item after a commit to Elasticsearch. The action is the
+// request as it was added to the BulkProcessor, resp is the response item
+// returned from Elasticsearch, and err describes the failure.
+type BulkItemFailureFunc func(action BulkableRequest, resp *BulkResponseItem, err error)
+
+// BulkStartFunc defines the signature of callbacks that are executed
+// when a BulkProcessor has been started.
+type BulkStartFunc func()
+
+// BulkStopFunc defines the signature of callbacks that are executed
+// when a BulkProcessor has been stopped. The stats are the final
+// statistics of the processor.
+type BulkStopFunc func(stats BulkProcessorStats)
+
 // Before specifies a function to be executed before bulk requests get comitted
 // to Elasticsearch.
 func (s *BulkProcessorService) Before(fn BulkBeforeFunc) *BulkProcessorService {
@@ -92,6 +113,20 @@ func (s *BulkProcessorService) After(fn BulkAfterFunc) *BulkProcessorService {
 	return s
 }
 
+// OnItemFailure specifies a function to be executed for every bulk request
+// that Elasticsearch reported as failed, i.e. with a status of 400 or higher.
+// Items that will be retried (see RetryItemStatusCodes) are not reported
+// while they are still enqueued. Once the Backoff gives up on them, they
+// are dropped from the queue and reported, including during the final
+// commit in Close. Without this callback, such items remain enqueued and
+// are sent again with the next commit. Use this e.g. to send failed
+// documents to a dead letter queue without having to scan the whole
+// BulkResponse.
+func (s *BulkProcessorService) OnItemFailure(fn BulkItemFailureFunc) *BulkProcessorService {
+	s.itemFailureFn = fn
+	return s
+}
+
 // Name is an optional name to identify this bulk processor.
 func (s *BulkProcessorService) Name(name string) *BulkProcessorService {
 	s.name = name
@@ -105,6 +140,22 @@ func (s *BulkProcessorService) Workers(num int) *BulkProcessorService {
 	return s
 }
 
+// Ordered specifies whether requests on the same document, i.e. with the
+// same index and id, are always handled by the same worker. This
+// preserves the order in which they were added, even with several
+// Workers. Requests without an id are spread across the workers.
+// It is disabled by default, where any worker may handle any request.
+//
+// If a request is retried because of one of the RetryItemStatusCodes,
+// all later successful requests of the same commit on the same document
+// are retried with it, so they are applied again in their original order.
+// Notice that these requests are therefore executed more than once, which
+// is only safe for idempotent requests like index or delete.
+func (s *BulkProcessorService) Ordered(ordered bool) *BulkProcessorService {
+	s.ordered = ordered
+	return s
+}
+
 // BulkActions specifies when to flush based on the number of actions
 // currently added. Defaults to 1000 and can be set to -1 to be disabled.
 func (s *BulkProcessorService) BulkActions(bulkActions int) *BulkProcessorService {
@@ -119,6 +170,22 @@ func (s *BulkProcessorService) BulkSize(bulkSize int) *BulkProcessorService {
 	return s
 }
 
+// MaxInFlightBytes limits the total size (in bytes) of the requests that
+// are queued in the workers or being committed. If the limit is reached,
+// Add blocks until a commit has finished and freed enough capacity,
+// providing back pressure to the caller. A single request larger than the
+// limit is accepted if nothing else is in flight. It is disabled by
+// default and can be set to 0 or -1 to be disabled.
+//
+// The limit is shared by all workers (see Workers). As each worker only
+// commits when it reaches BulkActions or BulkSize, the limit should be
+// at least the number of workers times BulkSize. Otherwise Add may block
+// until the FlushInterval elapses or Flush is called.
+func (s *BulkProcessorService) MaxInFlightBytes(maxInFlightBytes int64) *BulkProcessorService {
+	s.maxInFlightBytes = maxInFlightBytes
+	return s
+}
+
 // FlushInterval specifies when to flush at the end of the given interval.
 // This is disabled by default. If you want the bulk processor to
 // operate completely asynchronously, set both BulkActions and BulkSize to
@@ -135,7 +202,10 @@ func (s *BulkProcessorService) Stats(wantStats bool) *BulkProcessorService {
 	return s
 }
 
-// Backoff sets the backoff strategy to use for errors.
+// Backoff sets the backoff strategy to use for errors. It is used for
+// retrying commits of this processor only, including items that failed
+// with one of the RetryItemStatusCodes, and does not affect the retrier
+// of the client. It defaults to an exponential backoff.
 func (s *BulkProcessorService) Backoff(backoff Backoff) *BulkProcessorService {
 	s.backoff = backoff
 	return s
@@ -148,6 +218,25 @@ func (s *BulkProcessorService) RetryItemStatusCodes(retryItemStatusCodes ...int)
 	return s
 }
 
+// OnStart specifies a function to be executed once the BulkProcessor
+// has been started and its workers are running. It is invoked once for
+// every call to Start (or Do) on a stopped processor. The function may
+// use the processor, e.g. to call Flush.
+func (s *BulkProcessorService) OnStart(fn BulkStartFunc) *BulkProcessorService {
+	s.startFn = fn
+	return s
+}
+
+// OnStop specifies a function to be executed once the BulkProcessor
+// has been stopped, i.e. after Close has committed the outstanding
+// requests and all workers have finished. It receives the final
+// statistics of the processor, which are only gathered if Stats is
+// enabled. The function may use the processor, e.g. to Start it again.
+func (s *BulkProcessorService) OnStop(fn BulkStopFunc) *BulkProcessorService {
+	s.stopFn = fn
+	return s
+}
+
 // Do creates a new BulkProcessor and starts it.
 // Consider the BulkProcessor as a running instance that accepts bulk requests
 // and commits them to Elasticsearch, spreading the work across one or more
@@ -174,10 +263,15 @@ func (s *BulkProcessorService) Do(ctx context.Context) (*BulkProcessor, error) {
 		s.c,
 		s.beforeFn,
 		s.afterFn,
+		s.itemFailureFn,
+		s.startFn,
+		s.stopFn,
 		s.name,
 		s.numWorkers,
+		s.ordered,
 		s.bulkActions,
 		s.bulkSize,
+		s.maxInFlightBytes,
 		s.flushInterval,
 		s.wantStats,
 		s.backoff,
@@ -258,10 +352,16 @@ type BulkProcessor struct {
 	c                    *Client
 	beforeFn             BulkBeforeFunc
 	afterFn              BulkAfterFunc
+	itemFailureFn        BulkItemFailureFunc
+	startFn              BulkStartFunc
+	stopFn               BulkStopFunc
 	name                 string
 	bulkActions          int
 	bulkSize             int
+	maxInFlightBytes     int64
 	numWorkers           int
+	ordered              bool
+	nextWorker           uint32 // used to spread requests without an id in ordered mode
 	executionId          int64
 	requestsC            chan BulkableRequest
 	workerWg             sync.WaitGroup
@@ -278,6 +378,10 @@ type BulkProcessor struct {
 	statsMu sync.Mutex // guards the following block
 	stats   *BulkProcessorStats
 
+	inFlightMu    sync.Mutex // guards the following block
+	inFlightCond  *sync.Cond // signalled when in-flight bytes are released
+	inFlightBytes int64
+
 	stopReconnC chan struct{} // channel to signal stop reconnection attempts
 }
 
@@ -285,36 +389,47 @@ func newBulkProcessor(
 	client *Client,
 	beforeFn BulkBeforeFunc,
 	afterFn BulkAfterFunc,
+	itemFailureFn BulkItemFailureFunc,
+	startFn BulkStartFunc,
+	stopFn BulkStopFunc,
 	name string,
 	numWorkers int,
+	ordered bool,
 	bulkActions int,
 	bulkSize int,
+	maxInFlightBytes int64,
 	flushInterval time.Duration,
 	wantStats bool,
 	backoff Backoff,
 	retryItemStatusCodes map[int]struct{}) *BulkProcessor {
-	return &BulkProcessor{
+	p := &BulkProcessor{
 		c:                    client,
 		beforeFn:             beforeFn,
 		afterFn:              afterFn,
+		itemFailureFn:        itemFailureFn,
+		startFn:              startFn,
+		stopFn:               stopFn,
 		name:                 name,
 		numWorkers:           numWorkers,
+		ordered:              ordered,
 		bulkActions:          bulkActions,
 		bulkSize:             bulkSize,
+		maxInFlightBytes:     maxInFlightBytes,
 		flushInterval:        flushInterval,
 		wantStats:            wantStats,
 		retryItemStatusCodes: retryItemStatusCodes,
 		backoff:              backoff,
 	}
+	p.inFlightCond = sync.NewCond(&p.inFlightMu)
+	return p
 }
 
 // Start starts the bulk processor. If the processor is already started,
 // nil is returned.
 func (p *BulkProcessor) Start(ctx context.Context) error {
 	p.startedMu.Lock()
-	defer p.startedMu.Unlock()
-
 	if p.started {
+		p.startedMu.Unlock()
 		return nil
 	}
 
@@ -327,6 +442,9 @@ func (p *BulkProcessor) Start(ctx context.Context) error {
 	p.executionId = 0
 	p.stats = newBulkProcessorStats(p.numWorkers)
 	p.stopReconnC = make(chan struct{})
+	p.inFlightMu.Lock()
+	p.inFlightBytes = 0
+	p.inFlightMu.Unlock()
 
 	// Create and start up workers.
 	p.workers = make([]*bulkWorker, p.numWorkers)
@@ -343,6 +461,12 @@ func (p *BulkProcessor) Start(ctx context.Context) error {
 	}
 
 	p.started = true
+	p.startedMu.Unlock()
+
+	// Invoke start callback outside the lock, so that it may use the processor
+	if p.startFn != nil {
+		p.startFn()
+	}
 
 	return nil
 }
@@ -358,10 +482,10 @@ func (p *BulkProcessor) Stop() error {
 // By implementing Close, BulkProcessor implements the io.Closer interface.
 func (p *BulkProcessor) Close() error {
 	p.startedMu.Lock()
-	defer p.startedMu.Unlock()
 
 	// Already stopped? Do nothing.
 	if !p.started {
+		p.startedMu.Unlock()
 		return nil
 	}
 
@@ -381,9 +505,27 @@ func (p *BulkProcessor) Close() error {
 
 	// Stop all workers.
 	close(p.requestsC)
+	if p.ordered {
+		for _, w := range p.workers {
+			close(w.requestsC)
+		}
+	}
 	p.workerWg.Wait()
 
+	// Requests that could not be committed are dropped
+	p.inFlightMu.Lock()
+	p.inFlightBytes = 0
+	p.inFlightCond.Broadcast()
+	p.inFlightMu.Unlock()
+
 	p.started = false
+	stats := p.Stats()
+	p.startedMu.Unlock()
+
+	// Invoke stop callback outside the lock, so that it may use the processor
+	if p.stopFn != nil {
+		p.stopFn(stats)
+	}
 
 	return nil
 }
@@ -400,10 +542,76 @@ func (p *BulkProcessor) Stats() BulkProcessorStats {
 // Add adds a single request to commit by the BulkProcessorService.
 //
 // The caller is responsible for setting the index and type on the request.
+//
+// If MaxInFlightBytes is set, Add blocks while adding the request would
+// exceed the limit.
 func (p *BulkProcessor) Add(request BulkableRequest) {
+	if p.maxInFlightBytes > 0 {
+		p.acquireInFlightBytes(bulkableRequestSizeInBytes(request))
+	}
+	if p.ordered {
+		p.workers[p.workerFor(request)].requestsC <- request
+		return
+	}
 	p.requestsC <- request
 }
 
+// workerFor returns the index of the worker that handles the given
+// request in ordered mode.
+func (p *BulkProcessor) workerFor(request BulkableRequest) int {
+	key := bulkableRequestKey(request)
+	if key == "" {
+		return int(atomic.AddUint32(&p.nextWorker, 1) % uint32(len(p.workers)))
+	}
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(p.workers)))
+}
+
+// bulkableRequestKey returns the index and id of the document the request
+// refers to, or an empty string if the request has no id.
+func bulkableRequestKey(request BulkableRequest) string {
+	var index, id string
+	switch r := request.(type) {
+	case *BulkIndexRequest:
+		index, id = r.index, r.id
+	case *BulkUpdateRequest:
+		index, id = r.index, r.id
+	case *BulkDeleteRequest:
+		index, id = r.index, r.id
+	}
+	if id == "" {
+		return ""
+	}
+	return index + "/" + id
+}
+
+// acquireInFlightBytes waits until size bytes can be added to the
+// requests in flight without exceeding MaxInFlightBytes.
+func (p *BulkProcessor) acquireInFlightBytes(size int64) {
+	p.inFlightMu.Lock()
+	defer p.inFlightMu.Unlock()
+	for p.inFlightBytes > 0 && p.inFlightBytes+size > p.maxInFlightBytes {
+		p.inFlightCond.Wait()
+	}
+	p.inFlightBytes += size
+}
+
+// releaseInFlightBytes removes size bytes from the requests in flight
+// and wakes up blocked calls to Add.
+func (p *BulkProcessor) releaseInFlightBytes(size int64) {
+	if p.maxInFlightBytes <= 0 || size <= 0 {
+		return
+	}
+	p.inFlightMu.Lock()
+	defer p.inFlightMu.Unlock()
+	p.inFlightBytes -= size
+	if p.inFlightBytes < 0 {
+		p.inFlightBytes = 0
+	}
+	p.inFlightCond.Broadcast()
+}
+
 // Flush manually asks all workers to commit their outstanding requests.
 // It returns only when all workers acknowledge completion.
 func (p *BulkProcessor) Flush() error {
@@ -448,18 +656,25 @@ type bulkWorker struct {
 	bulkActions int
 	bulkSize    int
 	service     *BulkService
+	requestsC   chan BulkableRequest
 	flushC      chan struct{}
 	flushAckC   chan struct{}
 }
 
-// newBulkWorker creates a new bulkWorker instance.
+// newBulkWorker creates a new bulkWorker instance. In ordered mode, every
+// worker receives requests on its own channel.
 func newBulkWorker(p *BulkProcessor, i int) *bulkWorker {
+	requestsC := p.requestsC
+	if p.ordered {
+		requestsC = make(chan BulkableRequest)
+	}
 	return &bulkWorker{
 		p:           p,
 		i:           i,
 		bulkActions: p.bulkActions,
 		bulkSize:    p.bulkSize,
 		service:     NewBulkService(p.c),
+		requestsC:   requestsC,
 		flushC:      make(chan struct{}),
 		flushAckC:   make(chan struct{}),
 	}
@@ -478,7 +693,7 @@ func (w *bulkWorker) work(ctx context.Context) {
 	for !stop {
 		var err error
 		select {
-		case req, open := <-w.p.requestsC:
+		case req, open := <-w.requestsC:
 			if open {
 				// Received a new request
 				if _, err = req.Source(); err == nil {
@@ -525,6 +740,7 @@ func (w *bulkWorker) work(ctx context.Context) {
 // invoking callbacks as specified.
 func (w *bulkWorker) commit(ctx context.Context) error {
 	var res *BulkResponse
+	var resReqs []BulkableRequest // requests that res is the response for
 
 	// commitFunc will commit bulk requests and, on failure, be retried
 	// via exponential backoff
@@ -533,22 +749,41 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 		// Save requests because they will be reset in service.Do
 		reqs := w.service.requests
 		res, err = w.service.Do(ctx)
+		resReqs = reqs
+		if err == nil && w.p.itemFailureFn != nil {
+			// Report items that failed and will not be retried
+			w.notifyItemFailures(reqs, res, false)
+		}
 		if err == nil {
 			// Overall bulk request was OK.  But each bulk response item also has a status
 			if w.p.retryItemStatusCodes != nil && len(w.p.retryItemStatusCodes) > 0 {
 				// Check res.Items since some might be soft failures
 				if res.Items != nil && res.Errors {
 					// res.Items will be 1 to 1 with reqs in same order
+					retry := make([]bool, len(reqs))
+					failed := make([]bool, len(reqs))
 					for i, item := range res.Items {
+						if i >= len(reqs) {
+							break
+						}
 						for _, result := range item {
 							if _, found := w.p.retryItemStatusCodes[result.Status]; found {
-								w.service.Add(reqs[i])
-								if err == nil {
-									err = ErrBulkItemRetry
-								}
+								retry[i] = true
+							}
+							if result.Status >= 400 {
+								failed[i] = true
 							}
 						}
 					}
+					if w.p.ordered {
+						retryLaterRequestsOnSameDocument(reqs, retry, failed)
+					}
+					for i, req := range reqs {
+						if retry[i] {
+							w.service.Add(req)
+							err = ErrBulkItemRetry
+						}
+					}
 				}
 			}
 		}
@@ -570,6 +805,7 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 
 	// Save requests because they will be reset in commitFunc
 	reqs := w.service.requests
+	queuedBytes := w.service.EstimatedSizeInBytes()
 
 	// Invoke before callback
 	if w.p.beforeFn != nil {
@@ -579,10 +815,28 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 	// Commit bulk requests
 	err := RetryNotify(commitFunc, w.p.backoff, notifyFunc)
 	w.updateStats(res)
+
+	// If the backoff gave up on items with a retriable status, they are
+	// dropped and reported as failed instead of being retried forever.
+	// Without an OnItemFailure callback, they remain enqueued and are
+	// sent again with the next commit.
+	retriesExhausted := err == ErrBulkItemRetry && w.p.itemFailureFn != nil
+	if retriesExhausted {
+		w.service.Reset()
+	}
+
+	// Requests that are still enqueued, e.g. after a connection error,
+	// remain in flight
+	w.p.releaseInFlightBytes(queuedBytes - w.service.EstimatedSizeInBytes())
 	if err != nil {
 		w.p.c.errorf("elastic: bulk processor %q failed: %v", w.p.name, err)
 	}
 
+	// Report the items that were given up on
+	if retriesExhausted {
+		w.notifyItemFailures(resReqs, res, true)
+	}
+
 	// Invoke after callback
 	if w.p.afterFn != nil {
 		w.p.afterFn(id, reqs, res, err)
@@ -591,6 +845,49 @@ func (w *bulkWorker) commit(ctx context.Context) error {
 	return err
 }
 
+// retryLaterRequestsOnSameDocument marks every successful request for
+// retry that follows a request marked for retry on the same document.
+// This keeps the requests on a document in order when retrying in
+// ordered mode. Requests that failed otherwise are not retried.
+func retryLaterRequestsOnSameDocument(reqs []BulkableRequest, retry, failed []bool) {
+	retryKeys := make(map[string]bool)
+	for i, req := range reqs {
+		key := bulkableRequestKey(req)
+		if key == "" {
+			continue
+		}
+		if retry[i] {
+			retryKeys[key] = true
+		} else if retryKeys[key] && !failed[i] {
+			retry[i] = true
+		}
+	}
+}
+
+// notifyItemFailures invokes the item failure callback for every item
+// in res that failed. If retriable is true, only items with one of the
+// RetryItemStatusCodes are reported, otherwise only the other items.
+func (w *bulkWorker) notifyItemFailures(reqs []BulkableRequest, res *BulkResponse, retriable bool) {
+	if res == nil || !res.Errors {
+		return
+	}
+	// res.Items will be 1 to 1 with reqs in same order
+	for i, item := range res.Items {
+		if i >= len(reqs) {
+			break
+		}
+		for _, result := range item {
+			if result == nil || result.Status < 400 {
+				continue
+			}
+			if _, found := w.p.retryItemStatusCodes[result.Status]; found != retriable {
+				continue
+			}
+			w.p.itemFailureFn(reqs[i], result, &Error{Status: result.Status, Details: result.Error})
+		}
+	}
+}
+
 func (w *bulkWorker) waitForActiveConnection(ready chan<- struct{}) {
 	defer close(ready)
 
@@ -6,9 +6,15 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"math/rand"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -427,3 +433,629 @@ func testBulkProcessor(t *testing.T, numDocs int, svc *BulkProcessorService) {
 		t.Fatalf("expected %d documents; got: %d", numDocs, count)
 	}
 }
+
+func TestBulkProcessorOnItemFailure(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"took":3,"errors":true,"items":[`+
+			`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":201}},`+
+			`{"index":{"_index":"elastic-test","_type":"doc","_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}},`+
+			`{"delete":{"_index":"elastic-test","_type":"doc","_id":"3","status":404}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		failures = make(map[BulkableRequest]*BulkResponseItem)
+		errs     []error
+	)
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		OnItemFailure(func(action BulkableRequest, resp *BulkResponseItem, err error) {
+			mu.Lock()
+			failures[action] = resp
+			errs = append(errs, err)
+			mu.Unlock()
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req1 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere"})
+	req2 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("2").Doc(tweet{User: "sandrae"})
+	req3 := NewBulkDeleteRequest().Index(testIndexName).Type("doc").Id("3")
+	p.Add(req1)
+	p.Add(req2)
+	p.Add(req3)
+
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want, have := 2, len(failures); want != have {
+		t.Fatalf("expected %d failed items; got: %d", want, have)
+	}
+	if _, found := failures[req1]; found {
+		t.Errorf("expected request %v to succeed", req1)
+	}
+	if resp := failures[req2]; resp == nil || resp.Status != 400 || resp.Id != "2" {
+		t.Errorf("expected request %v to fail with status 400; got: %+v", req2, resp)
+	}
+	if resp := failures[req3]; resp == nil || resp.Status != 404 || resp.Id != "3" {
+		t.Errorf("expected request %v to fail with status 404; got: %+v", req3, resp)
+	}
+	for _, err := range errs {
+		if err == nil {
+			t.Errorf("expected error; got: %v", err)
+		}
+	}
+}
+
+func TestBulkProcessorOnItemFailureWhenRetriesExhausted(t *testing.T) {
+	var calls int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt64(&calls, 1) == 1 {
+			fmt.Fprintln(w, `{"took":1,"errors":true,"items":[`+
+				`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}},`+
+				`{"index":{"_index":"elastic-test","_type":"doc","_id":"2","status":400,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}]}`)
+			return
+		}
+		// Only the rejected item is retried, and rejected again
+		fmt.Fprintln(w, `{"took":1,"errors":true,"items":[`+
+			`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		failures = make(map[BulkableRequest][]int)
+		afterErr error
+	)
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		Backoff(&bulkProcessorStubBackoff{max: 1}).
+		OnItemFailure(func(action BulkableRequest, resp *BulkResponseItem, err error) {
+			mu.Lock()
+			failures[action] = append(failures[action], resp.Status)
+			mu.Unlock()
+		}).
+		After(func(executionId int64, requests []BulkableRequest, response *BulkResponse, err error) {
+			afterErr = err
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req1 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere"})
+	req2 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("2").Doc(tweet{User: "sandrae"})
+	p.Add(req1)
+	p.Add(req2)
+
+	// The final commit in Close gives up on the rejected item
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := int64(2), atomic.LoadInt64(&calls); want != have {
+		t.Errorf("expected %d bulk requests; got: %d", want, have)
+	}
+	if afterErr != ErrBulkItemRetry {
+		t.Errorf("expected After to receive %v; got: %v", ErrBulkItemRetry, afterErr)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want, have := []int{429}, failures[req1]; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected request %v to be reported once with %v; got: %v", req1, want, have)
+	}
+	if want, have := []int{400}, failures[req2]; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected request %v to be reported once with %v; got: %v", req2, want, have)
+	}
+}
+
+func TestBulkProcessorKeepsRetriableItemsWithoutOnItemFailure(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		bodies []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mu.Lock()
+		bodies = append(bodies, string(body))
+		n := len(bodies)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if n <= 2 {
+			fmt.Fprintln(w, `{"took":1,"errors":true,"items":[`+
+				`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}}]}`)
+			return
+		}
+		fmt.Fprintln(w, `{"took":1,"errors":false,"items":[`+
+			`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":201}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		Backoff(&bulkProcessorStubBackoff{max: 1}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere"}))
+
+	// The backoff gives up on the rejected item, but it remains enqueued
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	// The final commit in Close sends it again
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if want, have := 3, len(bodies); want != have {
+		t.Fatalf("expected %d bulk requests; got: %d", want, have)
+	}
+	for i, body := range bodies {
+		if !strings.Contains(body, `"_id":"1"`) {
+			t.Errorf("expected bulk request #%d to contain document 1; got: %s", i+1, body)
+		}
+	}
+}
+
+// bulkProcessorStubBackoff is a Backoff that records its invocations.
+type bulkProcessorStubBackoff struct {
+	mu      sync.Mutex
+	retries []int
+	max     int
+}
+
+func (b *bulkProcessorStubBackoff) Next(retry int) (time.Duration, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.retries = append(b.retries, retry)
+	return 0, retry <= b.max
+}
+
+func TestBulkProcessorBackoff(t *testing.T) {
+	var calls int64
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt64(&calls, 1) <= 2 {
+			// Simulate the cluster rejecting the item due to back pressure
+			fmt.Fprintln(w, `{"took":1,"errors":true,"items":[`+
+				`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":429,"error":{"type":"es_rejected_execution_exception","reason":"rejected execution"}}}]}`)
+			return
+		}
+		fmt.Fprintln(w, `{"took":1,"errors":false,"items":[`+
+			`{"index":{"_index":"elastic-test","_type":"doc","_id":"1","status":201}}]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	backoff := &bulkProcessorStubBackoff{max: 5}
+	var afterErr error
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		Backoff(backoff).
+		After(func(executionId int64, requests []BulkableRequest, response *BulkResponse, err error) {
+			afterErr = err
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere"}))
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if afterErr != nil {
+		t.Errorf("expected commit to succeed eventually; got: %v", afterErr)
+	}
+	if want, have := int64(3), atomic.LoadInt64(&calls); want != have {
+		t.Errorf("expected %d bulk requests; got: %d", want, have)
+	}
+	backoff.mu.Lock()
+	defer backoff.mu.Unlock()
+	if want, have := []int{1, 2}, backoff.retries; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected backoff to be called with %v; got: %v", want, have)
+	}
+}
+
+func TestBulkProcessorMaxInFlightBytes(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"took":1,"errors":false,"items":[]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req1 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere"})
+	req2 := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("2").Doc(tweet{User: "sandrae"})
+
+	// Only one of the requests fits into the limit
+	p, err := client.BulkProcessor().
+		Workers(2).
+		BulkActions(-1).
+		BulkSize(-1).
+		MaxInFlightBytes(bulkableRequestSizeInBytes(req1) + 1).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	p.Add(req1)
+
+	added := make(chan struct{})
+	go func() {
+		p.Add(req2)
+		close(added)
+	}()
+
+	select {
+	case <-added:
+		t.Fatal("expected Add to block when MaxInFlightBytes is reached")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	if err := p.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-added:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected Add to unblock after Flush")
+	}
+}
+
+func TestBulkProcessorOnStartAndOnStop(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every index request consists of two lines: action and document
+		body, _ := ioutil.ReadAll(r.Body)
+		n := strings.Count(string(body), "\n") / 2
+		items := make([]string, n)
+		for i := range items {
+			items[i] = fmt.Sprintf(`{"index":{"_index":"elastic-test","_type":"doc","_id":"%d","status":201}}`, i+1)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"took":1,"errors":false,"items":[%s]}`, strings.Join(items, ","))
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		started int
+		stopped int
+		stats   BulkProcessorStats
+	)
+	p, err := client.BulkProcessor().
+		Workers(2).
+		BulkActions(2).
+		Stats(true).
+		OnStart(func() {
+			started++
+		}).
+		OnStop(func(st BulkProcessorStats) {
+			stopped++
+			stats = st
+		}).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, started; want != have {
+		t.Fatalf("expected OnStart to be called %d time(s); got: %d", want, have)
+	}
+	if want, have := 0, stopped; want != have {
+		t.Fatalf("expected OnStop to be called %d time(s); got: %d", want, have)
+	}
+
+	const numDocs = 5
+	for i := 1; i <= numDocs; i++ {
+		p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id(fmt.Sprint(i)).Doc(tweet{User: "olivere"}))
+	}
+
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Closing a stopped processor is a no-op
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	if want, have := 1, started; want != have {
+		t.Errorf("expected OnStart to be called %d time(s); got: %d", want, have)
+	}
+	if want, have := 1, stopped; want != have {
+		t.Fatalf("expected OnStop to be called %d time(s); got: %d", want, have)
+	}
+	if want, have := int64(numDocs), stats.Indexed; want != have {
+		t.Errorf("expected %d indexed documents in final stats; got: %d", want, have)
+	}
+	if want, have := int64(numDocs), stats.Succeeded; want != have {
+		t.Errorf("expected %d succeeded documents in final stats; got: %d", want, have)
+	}
+	if stats.Committed == 0 {
+		t.Errorf("expected commits in final stats; got: %d", stats.Committed)
+	}
+}
+
+func TestBulkProcessorOnStartAndOnStopUseProcessor(t *testing.T) {
+	client, err := NewSimpleClient(SetURL("http://127.0.0.1:9200"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		p       *BulkProcessor
+		started int
+		stopped int
+	)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		p, err = client.BulkProcessor().
+			OnStart(func() {
+				started++
+				if p == nil {
+					return // invoked from Do
+				}
+				if err := p.Flush(); err != nil {
+					t.Errorf("expected no error from Flush; got: %v", err)
+				}
+			}).
+			OnStop(func(BulkProcessorStats) {
+				stopped++
+				if err := p.Close(); err != nil {
+					t.Errorf("expected no error from Close; got: %v", err)
+				}
+				if stopped == 1 {
+					if err := p.Start(context.Background()); err != nil {
+						t.Errorf("expected no error from Start; got: %v", err)
+					}
+				}
+			}).
+			Do(context.Background())
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		// OnStop restarts the processor once
+		if err := p.Close(); err != nil {
+			t.Error(err)
+		}
+		if err := p.Close(); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected callbacks to use the processor without deadlocking")
+	}
+
+	if want, have := 2, started; want != have {
+		t.Errorf("expected OnStart to be called %d time(s); got: %d", want, have)
+	}
+	if want, have := 2, stopped; want != have {
+		t.Errorf("expected OnStop to be called %d time(s); got: %d", want, have)
+	}
+}
+
+func TestBulkProcessorOrdered(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seqs = make(map[string][]int) // document id -> sequence numbers in the order received
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		// Slow down some commits to provoke reordering between workers
+		time.Sleep(time.Duration(rand.Intn(5)) * time.Millisecond)
+		mu.Lock()
+		for i := 0; i+1 < len(lines); i += 2 {
+			var action map[string]struct {
+				Id string `json:"_id"`
+			}
+			var doc struct {
+				Seq int `json:"seq"`
+			}
+			if err := json.Unmarshal([]byte(lines[i]), &action); err != nil {
+				t.Errorf("expected to decode action; got %v", err)
+			}
+			if err := json.Unmarshal([]byte(lines[i+1]), &doc); err != nil {
+				t.Errorf("expected to decode document; got %v", err)
+			}
+			id := action["index"].Id
+			seqs[id] = append(seqs[id], doc.Seq)
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"took":1,"errors":false,"items":[]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := client.BulkProcessor().
+		Workers(4).
+		BulkActions(1).
+		Ordered(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const numUpdates = 100
+	ids := []string{"1", "2", "3"}
+	for seq := 0; seq < numUpdates; seq++ {
+		for _, id := range ids {
+			p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id(id).Doc(map[string]interface{}{"seq": seq}))
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	for _, id := range ids {
+		if want, have := numUpdates, len(seqs[id]); want != have {
+			t.Fatalf("expected %d updates of document %q; got %d", want, id, have)
+		}
+		for i, seq := range seqs[id] {
+			if seq != i {
+				t.Fatalf("expected updates of document %q in submission order; got %v", id, seqs[id])
+			}
+		}
+	}
+}
+
+func TestBulkProcessorWorkerForIsStable(t *testing.T) {
+	p := newBulkProcessor(nil, nil, nil, nil, nil, nil, "", 4, true, -1, -1, 0, 0, false, nil, nil)
+	p.workers = make([]*bulkWorker, 4)
+
+	req := NewBulkUpdateRequest().Index(testIndexName).Type("doc").Id("1").Doc(map[string]interface{}{"seq": 1})
+	i := p.workerFor(req)
+	for n := 0; n < 10; n++ {
+		if want, have := i, p.workerFor(NewBulkDeleteRequest().Index(testIndexName).Type("doc").Id("1")); want != have {
+			t.Fatalf("expected requests on the same document to go to worker %d; got %d", want, have)
+		}
+	}
+
+	// Requests without an id are spread across the workers
+	seen := make(map[int]bool)
+	for n := 0; n < 4; n++ {
+		seen[p.workerFor(NewBulkIndexRequest().Index(testIndexName).Type("doc").Doc(map[string]interface{}{"seq": n}))] = true
+	}
+	if want, have := 4, len(seen); want != have {
+		t.Fatalf("expected requests without id to be spread across %d workers; got %d", want, have)
+	}
+}
+
+func TestBulkProcessorOrderedRetry(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls [][]string // id:seq of every request in each bulk request
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		var ops []string
+		for i := 0; i+1 < len(lines); i += 2 {
+			var action map[string]struct {
+				Id string `json:"_id"`
+			}
+			var doc struct {
+				Seq int `json:"seq"`
+			}
+			json.Unmarshal([]byte(lines[i]), &action)
+			json.Unmarshal([]byte(lines[i+1]), &doc)
+			ops = append(ops, fmt.Sprintf("%s:%d", action["index"].Id, doc.Seq))
+		}
+		mu.Lock()
+		calls = append(calls, ops)
+		n := len(calls)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		items := make([]string, len(ops))
+		for i := range ops {
+			status := 201
+			if n == 1 && i == 0 {
+				// Reject the first update of document 1
+				status = 429
+			}
+			items[i] = fmt.Sprintf(`{"index":{"_index":"elastic-test","_type":"doc","_id":"%s","status":%d}}`, strings.Split(ops[i], ":")[0], status)
+		}
+		fmt.Fprintf(w, `{"took":1,"errors":%v,"items":[%s]}`, n == 1, strings.Join(items, ","))
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := client.BulkProcessor().
+		BulkActions(-1).
+		BulkSize(-1).
+		Backoff(&bulkProcessorStubBackoff{max: 5}).
+		Ordered(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(map[string]interface{}{"seq": 1}))
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(map[string]interface{}{"seq": 2}))
+	p.Add(NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("2").Doc(map[string]interface{}{"seq": 1}))
+	if err := p.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := [][]string{
+		{"1:1", "1:2", "2:1"},
+		// The later update of document 1 is retried after the rejected one
+		{"1:1", "1:2"},
+	}
+	if !reflect.DeepEqual(expected, calls) {
+		t.Fatalf("expected bulk requests %v; got: %v", expected, calls)
+	}
+}
@@ -15,3 +15,28 @@ type BulkableRequest interface {
 	fmt.Stringer
 	Source() ([]string, error)
 }
+
+// -- Bulkable document --
+
+// BulkableDocument is implemented by domain objects that know where and
+// how they are stored in Elasticsearch. Use NewBulkableRequest to turn
+// a BulkableDocument into the appropriate BulkableRequest.
+type BulkableDocument interface {
+	// ID returns the identifier of the document.
+	ID() string
+	// Index returns the name of the index the document is stored in.
+	// If empty, the index set on the BulkService is used.
+	Index() string
+	// Deleted returns true if the document should be removed.
+	Deleted() bool
+}
+
+// NewBulkableRequest returns a BulkDeleteRequest for the given document
+// if it reports to be deleted, and a BulkIndexRequest with the document
+// as its source otherwise.
+func NewBulkableRequest(doc BulkableDocument) BulkableRequest {
+	if doc.Deleted() {
+		return NewBulkDeleteRequest().Index(doc.Index()).Id(doc.ID())
+	}
+	return NewBulkIndexRequest().Index(doc.Index()).Id(doc.ID()).Doc(doc)
+}
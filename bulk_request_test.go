@@ -0,0 +1,58 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+)
+
+type bulkableTweet struct {
+	Id        string `json:"-"`
+	User      string `json:"user"`
+	Message   string `json:"message"`
+	IsDeleted bool   `json:"-"`
+}
+
+func (t bulkableTweet) ID() string    { return t.Id }
+func (t bulkableTweet) Index() string { return "tweets" }
+func (t bulkableTweet) Deleted() bool { return t.IsDeleted }
+
+func TestNewBulkableRequest(t *testing.T) {
+	tests := []struct {
+		Doc      BulkableDocument
+		Expected []string
+	}{
+		// #0
+		{
+			Doc: bulkableTweet{Id: "1", User: "olivere", Message: "Welcome to Golang and Elasticsearch."},
+			Expected: []string{
+				`{"index":{"_index":"tweets","_id":"1"}}`,
+				`{"user":"olivere","message":"Welcome to Golang and Elasticsearch."}`,
+			},
+		},
+		// #1
+		{
+			Doc: bulkableTweet{Id: "2", User: "sandrae", IsDeleted: true},
+			Expected: []string{
+				`{"delete":{"_index":"tweets","_id":"2"}}`,
+			},
+		},
+	}
+
+	for i, test := range tests {
+		lines, err := NewBulkableRequest(test.Doc).Source()
+		if err != nil {
+			t.Fatalf("case #%d: expected no error, got: %v", i, err)
+		}
+		if len(lines) != len(test.Expected) {
+			t.Fatalf("case #%d: expected %d lines, got %d", i, len(test.Expected), len(lines))
+		}
+		for j, line := range lines {
+			if line != test.Expected[j] {
+				t.Errorf("case #%d: expected line #%d to be %s, got: %s", i, j, test.Expected[j], line)
+			}
+		}
+	}
+}
@@ -444,6 +444,114 @@ func TestFailedBulkRequests(t *testing.T) {
 	}
 }
 
+func TestBulkResponseFailedByStatusAndRetriableFailures(t *testing.T) {
+	js := `{
+  "took" : 2,
+  "errors" : true,
+  "items" : [ {
+    "index" : { "_index" : "elastic-test", "_type" : "doc", "_id" : "1", "status" : 201 }
+  }, {
+    "create" : {
+      "_index" : "elastic-test", "_type" : "doc", "_id" : "2", "status" : 409,
+      "error" : { "type" : "version_conflict_engine_exception", "reason" : "document already exists" }
+    }
+  }, {
+    "index" : {
+      "_index" : "elastic-test", "_type" : "doc", "_id" : "3", "status" : 429,
+      "error" : { "type" : "es_rejected_execution_exception", "reason" : "rejected execution" }
+    }
+  }, {
+    "index" : {
+      "_index" : "elastic-test", "_type" : "doc", "_id" : "4", "status" : 400,
+      "error" : { "type" : "mapper_parsing_exception", "reason" : "failed to parse" }
+    }
+  }, {
+    "index" : {
+      "_index" : "elastic-test", "_type" : "doc", "_id" : "5", "status" : 429,
+      "error" : { "type" : "es_rejected_execution_exception", "reason" : "rejected execution" }
+    }
+  } ]
+}`
+
+	var resp BulkResponse
+	err := json.Unmarshal([]byte(js), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byStatus := resp.FailedByStatus()
+	if want, have := 3, len(byStatus); want != have {
+		t.Fatalf("expected %d status codes; got: %d", want, have)
+	}
+	if _, found := byStatus[201]; found {
+		t.Errorf("expected no failures with status %d", 201)
+	}
+	for status, ids := range map[int][]string{409: {"2"}, 429: {"3", "5"}, 400: {"4"}} {
+		items := byStatus[status]
+		if want, have := len(ids), len(items); want != have {
+			t.Errorf("expected %d failures with status %d; got: %d", want, status, have)
+			continue
+		}
+		for i, id := range ids {
+			if items[i].Id != id {
+				t.Errorf("expected failure #%d with status %d to have id %q; got: %q", i, status, id, items[i].Id)
+			}
+		}
+	}
+
+	retriable := resp.RetriableFailures()
+	if want, have := 2, len(retriable); want != have {
+		t.Fatalf("expected %d retriable failures; got: %d", want, have)
+	}
+	for _, item := range retriable {
+		if item.Status != 429 {
+			t.Errorf("expected retriable failure with status %d; got: %d", 429, item.Status)
+		}
+	}
+
+	// No failures
+	var empty BulkResponse
+	if len(empty.FailedByStatus()) != 0 || len(empty.RetriableFailures()) != 0 {
+		t.Errorf("expected no failures for an empty response")
+	}
+}
+
+func TestBulkResponseSeqNoAndPrimaryTerm(t *testing.T) {
+	js := `{
+  "took" : 3,
+  "errors" : false,
+  "items" : [ {
+    "index" : {
+      "_index" : "elastic-test",
+      "_type" : "doc",
+      "_id" : "1",
+      "_version" : 1,
+      "result" : "created",
+      "_shards" : { "total" : 2, "successful" : 1, "failed" : 0 },
+      "_seq_no" : 5,
+      "_primary_term" : 2,
+      "status" : 201
+    }
+  } ]
+}`
+
+	var resp BulkResponse
+	err := json.Unmarshal([]byte(js), &resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	indexed := resp.Indexed()
+	if len(indexed) != 1 {
+		t.Fatalf("expected %d indexed items; got: %d", 1, len(indexed))
+	}
+	if want, have := int64(5), indexed[0].SeqNo; want != have {
+		t.Errorf("expected SeqNo = %d; got: %d", want, have)
+	}
+	if want, have := int64(2), indexed[0].PrimaryTerm; want != have {
+		t.Errorf("expected PrimaryTerm = %d; got: %d", want, have)
+	}
+}
+
 func TestBulkEstimatedSizeInBytes(t *testing.T) {
 	client := setupTestClientAndCreateIndex(t)
 
@@ -524,6 +632,35 @@ func TestBulkContentType(t *testing.T) {
 	}
 }
 
+func TestBulkWaitForActiveShards(t *testing.T) {
+	var waitForActiveShards []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		waitForActiveShards = append(waitForActiveShards, r.URL.Query().Get("wait_for_active_shards"))
+		fmt.Fprintln(w, `{}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"all", "2"} {
+		indexReq := NewBulkIndexRequest().Index(testIndexName).Type("doc").Id("1").Doc(tweet{User: "olivere", Message: "Welcome to Golang and Elasticsearch."})
+		if _, err := client.Bulk().WaitForActiveShards(value).Add(indexReq).Do(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, have := 2, len(waitForActiveShards); want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+	if want, have := "all", waitForActiveShards[0]; want != have {
+		t.Errorf("expected wait_for_active_shards = %q; got: %q", want, have)
+	}
+	if want, have := "2", waitForActiveShards[1]; want != have {
+		t.Errorf("expected wait_for_active_shards = %q; got: %q", want, have)
+	}
+}
+
 // -- Benchmarks --
 
 var benchmarkBulkEstimatedSizeInBytes int64
@@ -1434,6 +1434,11 @@ func (c *Client) DeleteByQuery(indices ...string) *DeleteByQueryService {
 	return NewDeleteByQueryService(c).Index(indices...)
 }
 
+// DeleteByQueryRethrottle changes the throttle of a running delete-by-query task.
+func (c *Client) DeleteByQueryRethrottle(taskId string) *DeleteByQueryRethrottleService {
+	return NewDeleteByQueryRethrottleService(c).TaskId(taskId)
+}
+
 // Update a document.
 func (c *Client) Update() *UpdateService {
 	return NewUpdateService(c)
@@ -1444,6 +1449,11 @@ func (c *Client) UpdateByQuery(indices ...string) *UpdateByQueryService {
 	return NewUpdateByQueryService(c).Index(indices...)
 }
 
+// UpdateByQueryRethrottle changes the throttle of a running update-by-query task.
+func (c *Client) UpdateByQueryRethrottle(taskId string) *UpdateByQueryRethrottleService {
+	return NewUpdateByQueryRethrottleService(c).TaskId(taskId)
+}
+
 // Bulk is the entry point to mass insert/update/delete documents.
 func (c *Client) Bulk() *BulkService {
 	return NewBulkService(c)
@@ -1462,6 +1472,11 @@ func (c *Client) Reindex() *ReindexService {
 	return NewReindexService(c)
 }
 
+// ReindexRethrottle changes the throttle of a running reindex task.
+func (c *Client) ReindexRethrottle(taskId string) *ReindexRethrottleService {
+	return NewReindexRethrottleService(c).TaskId(taskId)
+}
+
 // TermVectors returns information and statistics on terms in the fields
 // of a particular document.
 func (c *Client) TermVectors(index, typ string) *TermvectorsService {
@@ -1532,6 +1547,11 @@ func (c *Client) ClearScroll(scrollIds ...string) *ClearScrollService {
 	return NewClearScrollService(c).ScrollId(scrollIds...)
 }
 
+// PointInTime opens and closes point in time readers for searching.
+func (c *Client) PointInTime() *PointInTimeService {
+	return NewPointInTimeService(c)
+}
+
 // -- Indices APIs --
 
 // CreateIndex returns a service to create a new index.
@@ -1560,6 +1580,12 @@ func (c *Client) RolloverIndex(alias string) *IndicesRolloverService {
 	return NewIndicesRolloverService(c).Alias(alias)
 }
 
+// ResolveIndex resolves names and wildcard expressions into the matching
+// indices, aliases, and data streams.
+func (c *Client) ResolveIndex(names ...string) *IndicesResolveIndexService {
+	return NewIndicesResolveIndexService(c).Name(names...)
+}
+
 // TypeExists allows to check if one or more types exist in one or more indices.
 func (c *Client) TypeExists() *IndicesExistsTypeService {
 	return NewIndicesExistsTypeService(c)
@@ -1625,6 +1651,11 @@ func (c *Client) Flush(indices ...string) *IndicesFlushService {
 	return NewIndicesFlushService(c).Index(indices...)
 }
 
+// ClearCache clears all or specific caches of one or more indices.
+func (c *Client) ClearCache(indices ...string) *IndicesClearCacheService {
+	return NewIndicesClearCacheService(c).Index(indices...)
+}
+
 // SyncedFlush performs a synced flush.
 //
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.4/indices-synced-flush.html
@@ -1983,6 +2014,26 @@ func (c *Client) IndexNames() ([]string, error) {
 	return names, nil
 }
 
+// RegisterPercolatorQuery returns an IndexService that registers q as a
+// percolator query by indexing it as {"query": ...} into the given index
+// and id. The index must have a field named "query" that is mapped as type
+// "percolator". The document type is "doc" by default; use Type on the
+// returned service to change it, e.g. to "_doc", or Refresh to make the
+// query visible to subsequent percolations.
+func (c *Client) RegisterPercolatorQuery(index, id string, q Query) (*IndexService, error) {
+	if q == nil {
+		return nil, errors.New("elastic: RegisterPercolatorQuery expects a query")
+	}
+	src, err := q.Source()
+	if err != nil {
+		return nil, err
+	}
+	body := map[string]interface{}{
+		"query": src,
+	}
+	return c.Index().Index(index).Type("doc").Id(id).BodyJson(body), nil
+}
+
 // Ping checks if a given node in a cluster exists and (optionally)
 // returns some basic information about the Elasticsearch server,
 // e.g. the Elasticsearch version number.
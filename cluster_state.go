@@ -13,6 +13,27 @@ import (
 	"github.com/olivere/elastic/uritemplates"
 )
 
+const (
+	// ClusterStateMetricAll returns all sections of the cluster state.
+	ClusterStateMetricAll = "_all"
+	// ClusterStateMetricVersion returns the version of the cluster state.
+	ClusterStateMetricVersion = "version"
+	// ClusterStateMetricMasterNode returns the id of the elected master node.
+	ClusterStateMetricMasterNode = "master_node"
+	// ClusterStateMetricNodes returns the nodes of the cluster.
+	ClusterStateMetricNodes = "nodes"
+	// ClusterStateMetricRoutingTable returns the routing table.
+	ClusterStateMetricRoutingTable = "routing_table"
+	// ClusterStateMetricRoutingNodes returns the shards per node.
+	ClusterStateMetricRoutingNodes = "routing_nodes"
+	// ClusterStateMetricMetadata returns the metadata, e.g. indices and templates.
+	ClusterStateMetricMetadata = "metadata"
+	// ClusterStateMetricBlocks returns the cluster and index blocks.
+	ClusterStateMetricBlocks = "blocks"
+	// ClusterStateMetricCustoms returns custom sections, e.g. snapshots in progress.
+	ClusterStateMetricCustoms = "customs"
+)
+
 // ClusterStateService allows to get a comprehensive state information of the whole cluster.
 //
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/cluster-state.html
@@ -48,7 +69,7 @@ func (s *ClusterStateService) Index(indices ...string) *ClusterStateService {
 
 // Metric limits the information returned to the specified metric.
 // It can be one of: version, master_node, nodes, routing_table, metadata,
-// blocks, or customs.
+// blocks, or customs. See the ClusterStateMetric constants.
 func (s *ClusterStateService) Metric(metrics ...string) *ClusterStateService {
 	s.metrics = append(s.metrics, metrics...)
 	return s
@@ -148,6 +169,21 @@ func (s *ClusterStateService) buildURL() (string, url.Values, error) {
 
 // Validate checks if the operation is valid.
 func (s *ClusterStateService) Validate() error {
+	for _, metric := range s.metrics {
+		switch metric {
+		case ClusterStateMetricAll,
+			ClusterStateMetricVersion,
+			ClusterStateMetricMasterNode,
+			ClusterStateMetricNodes,
+			ClusterStateMetricRoutingTable,
+			ClusterStateMetricRoutingNodes,
+			ClusterStateMetricMetadata,
+			ClusterStateMetricBlocks,
+			ClusterStateMetricCustoms:
+		default:
+			return fmt.Errorf("unknown cluster state metric %q", metric)
+		}
+	}
 	return nil
 }
 
@@ -26,6 +26,51 @@ func TestClusterState(t *testing.T) {
 	}
 }
 
+func TestClusterStateWithMasterNodeAndVersion(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	res, err := client.ClusterState().
+		Metric(ClusterStateMetricMasterNode, ClusterStateMetricVersion).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatalf("expected res to be != nil; got: %v", res)
+	}
+	if res.ClusterName == "" {
+		t.Errorf("expected a cluster name; got: %q", res.ClusterName)
+	}
+	if res.MasterNode == "" {
+		t.Errorf("expected a master node; got: %q", res.MasterNode)
+	}
+	if res.Version <= 0 {
+		t.Errorf("expected a version > 0; got: %d", res.Version)
+	}
+	if res.StateUUID == "" {
+		t.Errorf("expected a state UUID; got: %q", res.StateUUID)
+	}
+	if len(res.Nodes) != 0 {
+		t.Errorf("expected no nodes; got: %d", len(res.Nodes))
+	}
+	if res.Metadata != nil {
+		t.Errorf("expected no metadata; got: %v", res.Metadata)
+	}
+}
+
+func TestClusterStateValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	err := client.ClusterState().Metric(ClusterStateMetricMasterNode, ClusterStateMetricVersion).Validate()
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	err = client.ClusterState().Metric("master_nodes").Validate()
+	if err == nil {
+		t.Fatal("expected error for unknown metric")
+	}
+}
+
 func TestClusterStateURLs(t *testing.T) {
 	tests := []struct {
 		Service        *ClusterStateService
@@ -67,6 +112,18 @@ func TestClusterStateURLs(t *testing.T) {
 			},
 			ExpectedPath: "/_cluster/state/nodes/twitter",
 		},
+		{
+			Service: &ClusterStateService{
+				indices: []string{"twitter"},
+				metrics: []string{"master_node", "version"},
+			},
+			ExpectedPath: "/_cluster/state/master_node%2Cversion/twitter",
+		},
+		{
+			Service:        NewClusterStateService(nil).Metric("metadata").FlatSettings(true),
+			ExpectedPath:   "/_cluster/state/metadata/_all",
+			ExpectedParams: url.Values{"flat_settings": []string{"true"}},
+		},
 		{
 			Service: &ClusterStateService{
 				indices:       []string{"twitter"},
@@ -153,14 +153,18 @@ func (s *CountService) Query(query Query) *CountService {
 	return s
 }
 
-// Routing specifies the routing value.
-func (s *CountService) Routing(routing string) *CountService {
-	s.routing = routing
+// Routing is a list of specific routing values to control the shards
+// the count will be executed on.
+func (s *CountService) Routing(routings ...string) *CountService {
+	s.routing = strings.Join(routings, ",")
 	return s
 }
 
 // TerminateAfter indicates the maximum count for each shard, upon reaching
-// which the query execution will terminate early.
+// which the query execution will terminate early. Notice that the count
+// returned by Do is then capped per shard, i.e. it is a lower bound of the
+// total number of matching documents. This is useful for cheap threshold
+// checks like "are there at least N matches".
 func (s *CountService) TerminateAfter(terminateAfter int) *CountService {
 	s.terminateAfter = &terminateAfter
 	return s
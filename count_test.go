@@ -50,6 +50,30 @@ func TestCountURL(t *testing.T) {
 	}
 }
 
+func TestCountRouting(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.Count(testIndexName).Routing("user1", "user2").buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "user1,user2", params.Get("routing"); want != have {
+		t.Fatalf("expected routing = %q; got: %q", want, have)
+	}
+}
+
+func TestCountTerminateAfter(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.Count(testIndexName).TerminateAfter(1000).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "1000", params.Get("terminate_after"); want != have {
+		t.Fatalf("expected terminate_after = %q; got: %q", want, have)
+	}
+}
+
 func TestCount(t *testing.T) {
 	client := setupTestClientAndCreateIndex(t)
 
@@ -124,4 +148,13 @@ func TestCount(t *testing.T) {
 	if count != 2 {
 		t.Errorf("expected Count = %d; got %d", 2, count)
 	}
+
+	// Count with terminate_after: the count is capped per shard
+	count, err = client.Count(testIndexName).TerminateAfter(1).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count < 1 || count > 3 {
+		t.Errorf("expected 1 <= Count <= %d; got %d", 3, count)
+	}
 }
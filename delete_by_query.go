@@ -48,6 +48,7 @@ type DeleteByQueryService struct {
 	searchTimeout          string
 	searchType             string
 	size                   *int
+	maxDocs                *int
 	slices                 interface{}
 	sort                   []string
 	stats                  []string
@@ -276,7 +277,7 @@ func (s *DeleteByQueryService) Scroll(scroll string) *DeleteByQueryService {
 	return s
 }
 
-// ScrollSize is the size on the scroll request powering the update_by_query.
+// ScrollSize is the size on the scroll request powering the delete_by_query.
 func (s *DeleteByQueryService) ScrollSize(scrollSize int) *DeleteByQueryService {
 	s.scrollSize = &scrollSize
 	return s
@@ -302,6 +303,13 @@ func (s *DeleteByQueryService) Size(size int) *DeleteByQueryService {
 	return s
 }
 
+// MaxDocs specifies the maximum number of documents to delete.
+// It is available as of Elasticsearch 7.3.
+func (s *DeleteByQueryService) MaxDocs(maxDocs int) *DeleteByQueryService {
+	s.maxDocs = &maxDocs
+	return s
+}
+
 // Slices represents the number of slices (default: 1).
 // It used to  be a number, but can be set to "auto" as of 6.3.
 //
@@ -587,6 +595,28 @@ func (s *DeleteByQueryService) Validate() error {
 	return nil
 }
 
+// getBody returns the body of the request, if any.
+func (s *DeleteByQueryService) getBody() (interface{}, error) {
+	if s.body != nil {
+		return s.body, nil
+	}
+	if s.query == nil && s.maxDocs == nil {
+		return nil, nil
+	}
+	source := make(map[string]interface{})
+	if s.query != nil {
+		src, err := s.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["query"] = src
+	}
+	if s.maxDocs != nil {
+		source["max_docs"] = *s.maxDocs
+	}
+	return source, nil
+}
+
 // Do executes the delete-by-query operation.
 func (s *DeleteByQueryService) Do(ctx context.Context) (*BulkIndexByScrollResponse, error) {
 	// Check pre-conditions
@@ -601,17 +631,9 @@ func (s *DeleteByQueryService) Do(ctx context.Context) (*BulkIndexByScrollRespon
 	}
 
 	// Set body if there is a query set
-	var body interface{}
-	if s.body != nil {
-		body = s.body
-	} else if s.query != nil {
-		src, err := s.query.Source()
-		if err != nil {
-			return nil, err
-		}
-		body = map[string]interface{}{
-			"query": src,
-		}
+	body, err := s.getBody()
+	if err != nil {
+		return nil, err
 	}
 
 	// Get response
@@ -656,17 +678,9 @@ func (s *DeleteByQueryService) DoAsync(ctx context.Context) (*StartTaskResult, e
 	}
 
 	// Set body if there is a query set
-	var body interface{}
-	if s.body != nil {
-		body = s.body
-	} else if s.query != nil {
-		src, err := s.query.Source()
-		if err != nil {
-			return nil, err
-		}
-		body = map[string]interface{}{
-			"query": src,
-		}
+	body, err := s.getBody()
+	if err != nil {
+		return nil, err
 	}
 
 	// Get HTTP response
@@ -6,6 +6,7 @@ package elastic
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
 )
 
@@ -78,6 +79,58 @@ func TestDeleteByQueryBuildURL(t *testing.T) {
 	}
 }
 
+func TestDeleteByQueryBuildURLWithScrollSize(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.DeleteByQuery(testIndexName).ScrollSize(500).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "500", params.Get("scroll_size"); want != have {
+		t.Errorf("expected scroll_size = %q; got: %q", want, have)
+	}
+}
+
+func TestDeleteByQueryBodyWithMaxDocs(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Service  *DeleteByQueryService
+		Expected string
+	}{
+		{
+			client.DeleteByQuery(testIndexName).Query(NewTermQuery("user", "olivere")).MaxDocs(2),
+			`{"max_docs":2,"query":{"term":{"user":"olivere"}}}`,
+		},
+		{
+			client.DeleteByQuery(testIndexName).MaxDocs(2),
+			`{"max_docs":2}`,
+		},
+		{
+			client.DeleteByQuery(testIndexName).Query(NewTermQuery("user", "olivere")),
+			`{"query":{"term":{"user":"olivere"}}}`,
+		},
+		{
+			client.DeleteByQuery(testIndexName),
+			`null`,
+		},
+	}
+
+	for i, test := range tests {
+		body, err := test.Service.getBody()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if want, have := test.Expected, string(data); want != have {
+			t.Errorf("case #%d: expected\n%s\ngot:\n%s", i+1, want, have)
+		}
+	}
+}
+
 func TestDeleteByQuery(t *testing.T) {
 	// client := setupTestClientAndCreateIndex(t, SetTraceLog(log.New(os.Stdout, "", 0)))
 	client := setupTestClientAndCreateIndex(t)
@@ -145,6 +198,42 @@ func TestDeleteByQuery(t *testing.T) {
 	}
 }
 
+func TestDeleteByQueryWithMaxDocs(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) // , SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	count, err := client.Count(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count < 2 {
+		t.Fatalf("expected at least %d documents; got: %d", 2, count)
+	}
+
+	// Delete only one of all documents
+	res, err := client.DeleteByQuery(testIndexName).
+		Query(NewMatchAllQuery()).
+		MaxDocs(1).
+		Refresh("true").
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatalf("expected response != nil; got: %v", res)
+	}
+	if want, have := int64(1), res.Deleted; want != have {
+		t.Errorf("expected Deleted = %d; got: %d", want, have)
+	}
+
+	newCount, err := client.Count(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := count-1, newCount; want != have {
+		t.Fatalf("expected Count = %d; got: %d", want, have)
+	}
+}
+
 func TestDeleteByQueryAsync(t *testing.T) {
 	// client := setupTestClientAndCreateIndex(t, SetTraceLog(log.New(os.Stdout, "", 0)))
 	client := setupTestClientAndCreateIndex(t)
@@ -168,6 +168,7 @@ func IsStatusCode(err interface{}, code int) bool {
 type ShardsInfo struct {
 	Total      int             `json:"total"`
 	Successful int             `json:"successful"`
+	Skipped    int             `json:"skipped,omitempty"` // shards skipped by the can_match pre-filter phase
 	Failed     int             `json:"failed"`
 	Failures   []*ShardFailure `json:"failures,omitempty"`
 }
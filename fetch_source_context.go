@@ -5,6 +5,7 @@
 package elastic
 
 import (
+	"errors"
 	"net/url"
 	"strings"
 )
@@ -56,8 +57,13 @@ func (fsc *FetchSourceContext) Exclude(excludes ...string) *FetchSourceContext {
 }
 
 // Source returns the JSON-serializable data to be used in a body.
+// It returns an error if fetching the _source is disabled but includes
+// or excludes are given, as they would silently be ignored.
 func (fsc *FetchSourceContext) Source() (interface{}, error) {
 	if !fsc.fetchSource {
+		if len(fsc.includes) > 0 || len(fsc.excludes) > 0 {
+			return nil, errors.New("elastic: FetchSourceContext has includes or excludes, but fetching the _source is disabled")
+		}
 		return false, nil
 	}
 	if len(fsc.includes) == 0 && len(fsc.excludes) == 0 {
@@ -26,20 +26,16 @@ func TestFetchSourceContextNoFetchSource(t *testing.T) {
 	}
 }
 
-func TestFetchSourceContextNoFetchSourceIgnoreIncludesAndExcludes(t *testing.T) {
-	builder := NewFetchSourceContext(false).Include("a", "b").Exclude("c")
-	src, err := builder.Source()
-	if err != nil {
-		t.Fatal(err)
-	}
-	data, err := json.Marshal(src)
-	if err != nil {
-		t.Fatalf("marshaling to JSON failed: %v", err)
-	}
-	got := string(data)
-	expected := `false`
-	if got != expected {
-		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+func TestFetchSourceContextNoFetchSourceWithIncludesOrExcludes(t *testing.T) {
+	tests := []*FetchSourceContext{
+		NewFetchSourceContext(false).Include("a", "b").Exclude("c"),
+		NewFetchSourceContext(false).Include("a*"),
+		NewFetchSourceContext(false).Exclude("c*"),
+	}
+	for i, builder := range tests {
+		if _, err := builder.Source(); err == nil {
+			t.Errorf("#%d: expected error when fetching the _source is disabled with includes or excludes", i)
+		}
 	}
 }
 
@@ -4,6 +4,8 @@
 
 package elastic
 
+import "fmt"
+
 // Highlight allows highlighting search results on one or more fields.
 // For details, see:
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-highlighting.html
@@ -227,6 +229,9 @@ func (hl *Highlight) Source() (interface{}, error) {
 			// Use a slice for the fields
 			var fields []map[string]interface{}
 			for _, field := range hl.fields {
+				if err := field.checkMatchedFields(hl.highlighterType); err != nil {
+					return nil, err
+				}
 				src, err := field.Source()
 				if err != nil {
 					return nil, err
@@ -240,6 +245,9 @@ func (hl *Highlight) Source() (interface{}, error) {
 			// Use a map for the fields
 			fields := make(map[string]interface{}, 0)
 			for _, field := range hl.fields {
+				if err := field.checkMatchedFields(hl.highlighterType); err != nil {
+					return nil, err
+				}
 				src, err := field.Source()
 				if err != nil {
 					return nil, err
@@ -328,6 +336,8 @@ func (f *HighlighterField) FragmentSize(fragmentSize int) *HighlighterField {
 	return f
 }
 
+// FragmentOffset sets the margin from which to start highlighting.
+// It is only supported by the fast vector highlighter (fvh).
 func (f *HighlighterField) FragmentOffset(fragmentOffset int) *HighlighterField {
 	f.fragmentOffset = fragmentOffset
 	return f
@@ -388,6 +398,9 @@ func (f *HighlighterField) Options(options map[string]interface{}) *HighlighterF
 	return f
 }
 
+// MatchedFields combines matches on multiple fields to highlight a
+// single field, e.g. sub-fields analyzed in different ways. It requires
+// the fast vector highlighter, i.e. HighlighterType("fvh").
 func (f *HighlighterField) MatchedFields(matchedFields ...string) *HighlighterField {
 	f.matchedFields = append(f.matchedFields, matchedFields...)
 	return f
@@ -403,7 +416,28 @@ func (f *HighlighterField) ForceSource(forceSource bool) *HighlighterField {
 	return f
 }
 
+// checkMatchedFields returns an error if matched fields are used with
+// a highlighter other than fvh. The highlighter type of the field takes
+// precedence over the given default, e.g. the type set on Highlight.
+func (f *HighlighterField) checkMatchedFields(defaultType *string) error {
+	if len(f.matchedFields) == 0 {
+		return nil
+	}
+	highlighterType := f.highlighterType
+	if highlighterType == nil {
+		highlighterType = defaultType
+	}
+	if highlighterType != nil && *highlighterType != "fvh" {
+		return fmt.Errorf("elastic: matched_fields of highlighted field %q require the fvh highlighter; got type %q", f.Name, *highlighterType)
+	}
+	return nil
+}
+
 func (f *HighlighterField) Source() (interface{}, error) {
+	if err := f.checkMatchedFields(nil); err != nil {
+		return nil, err
+	}
+
 	source := make(map[string]interface{})
 
 	if f.preTags != nil && len(f.preTags) > 0 {
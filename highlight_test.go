@@ -44,6 +44,53 @@ func TestHighlighterFieldWithOptions(t *testing.T) {
 	}
 }
 
+func TestHighlighterFieldWithFragmentOffsetAndMatchedFields(t *testing.T) {
+	field := NewHighlighterField("comment").
+		HighlighterType("fvh").
+		FragmentOffset(10).
+		MatchedFields("comment", "comment.plain")
+	src, err := field.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fragment_offset":10,"matched_fields":["comment","comment.plain"],"type":"fvh"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestHighlighterFieldWithMatchedFieldsRequiresFvh(t *testing.T) {
+	// Invalid type on the field
+	field := NewHighlighterField("comment").HighlighterType("unified").MatchedFields("comment", "comment.plain")
+	if _, err := field.Source(); err == nil {
+		t.Fatal("expected error for matched_fields with unified highlighter")
+	}
+	if _, err := NewHighlight().Fields(field).Source(); err == nil {
+		t.Fatal("expected error for matched_fields with unified highlighter")
+	}
+
+	// Invalid type inherited from the highlight
+	field = NewHighlighterField("comment").MatchedFields("comment", "comment.plain")
+	if _, err := NewHighlight().HighlighterType("plain").Fields(field).Source(); err == nil {
+		t.Fatal("expected error for matched_fields with plain highlighter")
+	}
+
+	// The type of the field takes precedence
+	field = NewHighlighterField("comment").HighlighterType("fvh").MatchedFields("comment", "comment.plain")
+	if _, err := NewHighlight().HighlighterType("plain").Fields(field).Source(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	field = NewHighlighterField("comment").MatchedFields("comment", "comment.plain")
+	if _, err := NewHighlight().HighlighterType("fvh").Fields(field).Source(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+}
+
 func TestHighlightWithStringField(t *testing.T) {
 	builder := NewHighlight().Field("grade")
 	src, err := builder.Source()
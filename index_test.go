@@ -201,6 +201,30 @@ func TestIndexValidate(t *testing.T) {
 	}
 }
 
+func TestIndexWaitForActiveShards(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		WaitForActiveShards string
+	}{
+		{"all"},
+		{"2"},
+	}
+
+	for i, test := range tests {
+		_, _, params, err := client.Index().Index(testIndexName).Type("doc").Id("1").
+			WaitForActiveShards(test.WaitForActiveShards).
+			buildURL()
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if want, have := test.WaitForActiveShards, params.Get("wait_for_active_shards"); want != have {
+			t.Errorf("case #%d: expected wait_for_active_shards = %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
 func TestIndexCreateExistsOpenCloseDelete(t *testing.T) {
 	// TODO: Find out how to make these test robust
 	t.Skip("test fails regularly with 409 (Conflict): " +
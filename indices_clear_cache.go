@@ -0,0 +1,187 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olivere/elastic/uritemplates"
+)
+
+// IndicesClearCacheService clears all or specific caches of one or more
+// indices. If no specific cache is selected, all caches are cleared.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/indices-clearcache.html
+// for details.
+type IndicesClearCacheService struct {
+	client            *Client
+	pretty            bool
+	index             []string
+	query             *bool
+	fielddata         *bool
+	request           *bool
+	fields            []string
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+	expandWildcards   string
+}
+
+// NewIndicesClearCacheService creates a new IndicesClearCacheService.
+func NewIndicesClearCacheService(client *Client) *IndicesClearCacheService {
+	return &IndicesClearCacheService{
+		client: client,
+		index:  make([]string, 0),
+	}
+}
+
+// Index is a list of index names; use `_all` or empty string for all indices.
+func (s *IndicesClearCacheService) Index(indices ...string) *IndicesClearCacheService {
+	s.index = append(s.index, indices...)
+	return s
+}
+
+// Query indicates whether to clear the query cache.
+func (s *IndicesClearCacheService) Query(query bool) *IndicesClearCacheService {
+	s.query = &query
+	return s
+}
+
+// Fielddata indicates whether to clear the fielddata cache.
+func (s *IndicesClearCacheService) Fielddata(fielddata bool) *IndicesClearCacheService {
+	s.fielddata = &fielddata
+	return s
+}
+
+// Request indicates whether to clear the request cache.
+func (s *IndicesClearCacheService) Request(request bool) *IndicesClearCacheService {
+	s.request = &request
+	return s
+}
+
+// Fields limits clearing the fielddata cache to the given fields.
+func (s *IndicesClearCacheService) Fields(fields ...string) *IndicesClearCacheService {
+	s.fields = append(s.fields, fields...)
+	return s
+}
+
+// IgnoreUnavailable indicates whether specified concrete indices should be
+// ignored when unavailable (missing or closed).
+func (s *IndicesClearCacheService) IgnoreUnavailable(ignoreUnavailable bool) *IndicesClearCacheService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices expression
+// resolves into no concrete indices. (This includes `_all` string or when
+// no indices have been specified).
+func (s *IndicesClearCacheService) AllowNoIndices(allowNoIndices bool) *IndicesClearCacheService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards specifies whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *IndicesClearCacheService) ExpandWildcards(expandWildcards string) *IndicesClearCacheService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *IndicesClearCacheService) Pretty(pretty bool) *IndicesClearCacheService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *IndicesClearCacheService) buildURL() (string, url.Values, error) {
+	// Build URL
+	var err error
+	var path string
+
+	if len(s.index) > 0 {
+		path, err = uritemplates.Expand("/{index}/_cache/clear", map[string]string{
+			"index": strings.Join(s.index, ","),
+		})
+	} else {
+		path = "/_cache/clear"
+	}
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.query != nil {
+		params.Set("query", fmt.Sprintf("%v", *s.query))
+	}
+	if s.fielddata != nil {
+		params.Set("fielddata", fmt.Sprintf("%v", *s.fielddata))
+	}
+	if s.request != nil {
+		params.Set("request", fmt.Sprintf("%v", *s.request))
+	}
+	if len(s.fields) > 0 {
+		params.Set("fields", strings.Join(s.fields, ","))
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprintf("%v", *s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *IndicesClearCacheService) Validate() error {
+	return nil
+}
+
+// Do executes the service.
+func (s *IndicesClearCacheService) Do(ctx context.Context) (*IndicesClearCacheResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(IndicesClearCacheResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// -- Result of a clear cache request.
+
+// IndicesClearCacheResponse is the outcome of IndicesClearCacheService.Do.
+type IndicesClearCacheResponse struct {
+	Shards *ShardsInfo `json:"_shards"`
+}
@@ -0,0 +1,100 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"net/url"
+	"testing"
+)
+
+func TestIndicesClearCacheBuildURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Service        *IndicesClearCacheService
+		ExpectedPath   string
+		ExpectedParams url.Values
+	}{
+		{
+			client.ClearCache(),
+			"/_cache/clear",
+			url.Values{},
+		},
+		{
+			client.ClearCache("index1", "index2").Query(true),
+			"/index1%2Cindex2/_cache/clear",
+			url.Values{"query": []string{"true"}},
+		},
+		{
+			client.ClearCache("index1").Fielddata(true).Fields("message", "user"),
+			"/index1/_cache/clear",
+			url.Values{"fielddata": []string{"true"}, "fields": []string{"message,user"}},
+		},
+		{
+			client.ClearCache("index1").Request(true).Query(false),
+			"/index1/_cache/clear",
+			url.Values{"request": []string{"true"}, "query": []string{"false"}},
+		},
+	}
+
+	for i, test := range tests {
+		path, params, err := test.Service.buildURL()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if want, have := test.ExpectedPath, path; want != have {
+			t.Errorf("case #%d: expected path %q; got: %q", i+1, want, have)
+		}
+		if want, have := test.ExpectedParams.Encode(), params.Encode(); want != have {
+			t.Errorf("case #%d: expected params %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestIndicesClearCacheFielddata(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	// Sorting on the message field populates the fielddata cache
+	_, err := client.Search(testIndexName).Sort("message", true).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Clear only the fielddata cache
+	res, err := client.ClearCache(testIndexName).
+		Fielddata(true).
+		Query(false).
+		Request(false).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected result; got: nil")
+	}
+	if res.Shards == nil {
+		t.Fatal("expected _shards; got: nil")
+	}
+	if res.Shards.Total == 0 {
+		t.Errorf("expected _shards.total > 0; got: %d", res.Shards.Total)
+	}
+	if res.Shards.Failed != 0 {
+		t.Errorf("expected _shards.failed = 0; got: %d", res.Shards.Failed)
+	}
+
+	// The fielddata cache of the message field must be empty now
+	stats, err := client.IndexStats(testIndexName).Metric("fielddata").Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	index, found := stats.Indices[testIndexName]
+	if !found || index == nil || index.Total == nil || index.Total.Fielddata == nil {
+		t.Fatalf("expected fielddata stats for index %q; got: %+v", testIndexName, stats)
+	}
+	if want, have := int64(0), index.Total.Fielddata.MemorySizeInBytes; want != have {
+		t.Errorf("expected fielddata memory size = %d; got: %d", want, have)
+	}
+}
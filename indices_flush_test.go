@@ -68,3 +68,26 @@ func TestFlushBuildURL(t *testing.T) {
 		}
 	}
 }
+
+func TestFlushWithForceAndWaitIfOngoing(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	_, params, err := client.Flush(testIndexName).Force(true).WaitIfOngoing(true).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "true", params.Get("force"); want != have {
+		t.Errorf("expected force = %q; got: %q", want, have)
+	}
+	if want, have := "true", params.Get("wait_if_ongoing"); want != have {
+		t.Errorf("expected wait_if_ongoing = %q; got: %q", want, have)
+	}
+
+	res, err := client.Flush(testIndexName).Force(true).WaitIfOngoing(true).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Errorf("expected res to be != nil; got: %v", res)
+	}
+}
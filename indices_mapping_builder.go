@@ -0,0 +1,173 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// MappingBuilder builds the mapping definition of a type, e.g. for use
+// with IndicesPutMappingService.Mapping.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/mapping.html
+// for details.
+type MappingBuilder struct {
+	dynamic    string
+	meta       map[string]interface{}
+	properties map[string]*PropertyMapping
+}
+
+// NewMappingBuilder creates a new MappingBuilder.
+func NewMappingBuilder() *MappingBuilder {
+	return &MappingBuilder{}
+}
+
+// Dynamic controls whether new fields are added dynamically. Valid values
+// are "true" (default), "false" (new fields are ignored), "strict" (new
+// fields are rejected), and, as of Elasticsearch 7.11, "runtime" (new
+// fields are added as runtime fields).
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/dynamic.html
+// for details.
+func (b *MappingBuilder) Dynamic(dynamic string) *MappingBuilder {
+	b.dynamic = dynamic
+	return b
+}
+
+// Meta sets custom meta data associated with the mapping, e.g. the
+// application version that created it. Elasticsearch stores, but
+// does not use it.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/mapping-meta-field.html
+// for details.
+func (b *MappingBuilder) Meta(meta map[string]interface{}) *MappingBuilder {
+	b.meta = meta
+	return b
+}
+
+// Property adds the mapping of a field.
+func (b *MappingBuilder) Property(name string, prop *PropertyMapping) *MappingBuilder {
+	if b.properties == nil {
+		b.properties = make(map[string]*PropertyMapping)
+	}
+	b.properties[name] = prop
+	return b
+}
+
+// Source returns the JSON-serializable mapping definition.
+func (b *MappingBuilder) Source() (map[string]interface{}, error) {
+	// {
+	//   "dynamic" : "strict",
+	//   "_meta" : {
+	//     "class" : "MyApp::User"
+	//   },
+	//   "properties" : {
+	//     "user" : { "type" : "keyword" }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	if b.dynamic != "" {
+		source["dynamic"] = b.dynamic
+	}
+	if len(b.meta) > 0 {
+		source["_meta"] = b.meta
+	}
+	if len(b.properties) > 0 {
+		props, err := propertyMappingsSource(b.properties)
+		if err != nil {
+			return nil, err
+		}
+		source["properties"] = props
+	}
+	return source, nil
+}
+
+// -- PropertyMapping --
+
+// PropertyMapping is the mapping of a single field, see MappingBuilder.Property.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/mapping-types.html
+// for details.
+type PropertyMapping struct {
+	typ        string
+	analyzer   string
+	fields     map[string]*PropertyMapping
+	properties map[string]*PropertyMapping
+}
+
+// NewPropertyMapping creates a new PropertyMapping for a field of the
+// given type, e.g. "text" or "keyword". The type may be empty for
+// object fields that only have properties.
+func NewPropertyMapping(typ string) *PropertyMapping {
+	return &PropertyMapping{typ: typ}
+}
+
+// Analyzer specifies the analyzer of a text field.
+func (p *PropertyMapping) Analyzer(analyzer string) *PropertyMapping {
+	p.analyzer = analyzer
+	return p
+}
+
+// Field adds a multi-field, i.e. indexes the same field in a different
+// way, e.g. as a keyword sub-field of a text field.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/multi-fields.html
+// for details.
+func (p *PropertyMapping) Field(name string, field *PropertyMapping) *PropertyMapping {
+	if p.fields == nil {
+		p.fields = make(map[string]*PropertyMapping)
+	}
+	p.fields[name] = field
+	return p
+}
+
+// Property adds the mapping of a sub-field of an object or nested field.
+func (p *PropertyMapping) Property(name string, prop *PropertyMapping) *PropertyMapping {
+	if p.properties == nil {
+		p.properties = make(map[string]*PropertyMapping)
+	}
+	p.properties[name] = prop
+	return p
+}
+
+// Source returns the JSON-serializable field mapping.
+func (p *PropertyMapping) Source() (map[string]interface{}, error) {
+	source := make(map[string]interface{})
+	if p.typ != "" {
+		source["type"] = p.typ
+	}
+	if p.analyzer != "" {
+		source["analyzer"] = p.analyzer
+	}
+	if len(p.fields) > 0 {
+		fields, err := propertyMappingsSource(p.fields)
+		if err != nil {
+			return nil, err
+		}
+		source["fields"] = fields
+	}
+	if len(p.properties) > 0 {
+		props, err := propertyMappingsSource(p.properties)
+		if err != nil {
+			return nil, err
+		}
+		source["properties"] = props
+	}
+	return source, nil
+}
+
+// propertyMappingsSource returns the JSON-serializable form of props.
+func propertyMappingsSource(props map[string]*PropertyMapping) (map[string]interface{}, error) {
+	source := make(map[string]interface{})
+	for name, prop := range props {
+		if prop == nil {
+			return nil, fmt.Errorf("elastic: missing mapping for field %q", name)
+		}
+		src, err := prop.Source()
+		if err != nil {
+			return nil, err
+		}
+		source[name] = src
+	}
+	return source, nil
+}
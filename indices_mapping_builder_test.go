@@ -0,0 +1,157 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestMappingBuilderWithDynamicAndMeta(t *testing.T) {
+	b := NewMappingBuilder().
+		Dynamic("strict").
+		Meta(map[string]interface{}{
+			"class":   "MyApp::User",
+			"version": map[string]interface{}{"min": "1.0", "max": "1.3"},
+		})
+	src, err := b.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_meta":{"class":"MyApp::User","version":{"max":"1.3","min":"1.0"}},"dynamic":"strict"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMappingBuilderEmpty(t *testing.T) {
+	src, err := NewMappingBuilder().Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMappingBuilderWithProperties(t *testing.T) {
+	b := NewMappingBuilder().
+		Dynamic("false").
+		Property("user", NewPropertyMapping("keyword")).
+		Property("message", NewPropertyMapping("text").
+			Analyzer("english").
+			Field("raw", NewPropertyMapping("keyword"))).
+		Property("location", NewPropertyMapping("object").
+			Property("city", NewPropertyMapping("keyword")).
+			Property("point", NewPropertyMapping("geo_point")))
+	src, err := b.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"dynamic":"false","properties":{"location":{"properties":{"city":{"type":"keyword"},"point":{"type":"geo_point"}},"type":"object"},"message":{"analyzer":"english","fields":{"raw":{"type":"keyword"}},"type":"text"},"user":{"type":"keyword"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMappingBuilderWithNilProperty(t *testing.T) {
+	if _, err := NewMappingBuilder().Property("user", nil).Source(); err == nil {
+		t.Fatal("expected error for missing property mapping; got: nil")
+	}
+}
+
+func TestMappingBuilderStrictRejectsUnknownFields(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	// Create index
+	createIndex, err := client.CreateIndex(testIndexName3).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if createIndex == nil {
+		t.Errorf("expected result to be != nil; got: %v", createIndex)
+	}
+
+	mapping, err := NewMappingBuilder().
+		Dynamic("strict").
+		Meta(map[string]interface{}{"class": "MyApp::User"}).
+		Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	putresp, err := client.PutMapping().Index(testIndexName3).Type("doc").BodyJson(mapping).Do(context.TODO())
+	if err != nil {
+		t.Fatalf("expected put mapping to succeed; got: %v", err)
+	}
+	if putresp == nil {
+		t.Fatalf("expected put mapping response; got: %v", putresp)
+	}
+	if !putresp.Acknowledged {
+		t.Fatalf("expected put mapping ack; got: %v", putresp.Acknowledged)
+	}
+
+	// Indexing a document with an unknown field must fail
+	_, err = client.Index().Index(testIndexName3).Type("doc").Id("1").
+		BodyJson(map[string]interface{}{"unknown": "field"}).
+		Do(context.TODO())
+	if err == nil {
+		t.Fatal("expected indexing an unknown field into a strict mapping to fail")
+	}
+	if !IsStatusCode(err, 400) {
+		t.Fatalf("expected status code 400; got: %v", err)
+	}
+}
+
+func TestMappingBuilderWithPutMapping(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	// Create index
+	createIndex, err := client.CreateIndex(testIndexName3).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if createIndex == nil {
+		t.Errorf("expected result to be != nil; got: %v", createIndex)
+	}
+
+	mapping := NewMappingBuilder().
+		Property("user", NewPropertyMapping("keyword")).
+		Property("message", NewPropertyMapping("text").Field("raw", NewPropertyMapping("keyword")))
+	putresp, err := client.PutMapping().Index(testIndexName3).Type("doc").Mapping(mapping).Do(context.TODO())
+	if err != nil {
+		t.Fatalf("expected put mapping to succeed; got: %v", err)
+	}
+	if putresp == nil {
+		t.Fatalf("expected put mapping response; got: %v", putresp)
+	}
+	if !putresp.Acknowledged {
+		t.Fatalf("expected put mapping ack; got: %v", putresp.Acknowledged)
+	}
+
+	fieldresp, err := client.GetFieldMapping().Index(testIndexName3).Type("doc").Field("message.raw").Do(context.TODO())
+	if err != nil {
+		t.Fatalf("expected get field mapping to succeed; got: %v", err)
+	}
+	if _, ok := fieldresp[testIndexName3]; !ok {
+		t.Fatalf("expected field mapping for index %q; got: %#v", testIndexName3, fieldresp)
+	}
+}
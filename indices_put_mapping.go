@@ -31,6 +31,7 @@ type IndicesPutMappingService struct {
 	timeout           string
 	bodyJson          map[string]interface{}
 	bodyString        string
+	mapping           *MappingBuilder
 }
 
 // NewPutMappingService is an alias for NewIndicesPutMappingService.
@@ -119,6 +120,13 @@ func (s *IndicesPutMappingService) BodyString(mapping string) *IndicesPutMapping
 	return s
 }
 
+// Mapping specifies the mapping definition via a MappingBuilder.
+// It takes precedence over BodyJson and BodyString.
+func (s *IndicesPutMappingService) Mapping(mapping *MappingBuilder) *IndicesPutMappingService {
+	s.mapping = mapping
+	return s
+}
+
 // buildURL builds the URL for the operation.
 func (s *IndicesPutMappingService) buildURL() (string, url.Values, error) {
 	var err error
@@ -171,7 +179,7 @@ func (s *IndicesPutMappingService) Validate() error {
 	if s.typ == "" {
 		invalid = append(invalid, "Type")
 	}
-	if s.bodyString == "" && s.bodyJson == nil {
+	if s.bodyString == "" && s.bodyJson == nil && s.mapping == nil {
 		invalid = append(invalid, "BodyJson")
 	}
 	if len(invalid) > 0 {
@@ -195,7 +203,13 @@ func (s *IndicesPutMappingService) Do(ctx context.Context) (*PutMappingResponse,
 
 	// Setup HTTP request body
 	var body interface{}
-	if s.bodyJson != nil {
+	if s.mapping != nil {
+		src, err := s.mapping.Source()
+		if err != nil {
+			return nil, err
+		}
+		body = src
+	} else if s.bodyJson != nil {
 		body = s.bodyJson
 	} else {
 		body = s.bodyString
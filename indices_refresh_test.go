@@ -79,3 +79,47 @@ func TestRefresh(t *testing.T) {
 		t.Fatal("expected result; got nil")
 	}
 }
+
+func TestRefreshMakesDocumentsVisible(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	// Disable periodic refreshes so that only an explicit refresh
+	// makes new documents visible to search
+	_, err := client.IndexPutSettings(testIndexName).BodyString(`{"index":{"refresh_interval":"-1"}}`).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tweet1 := tweet{User: "olivere", Message: "Welcome to Golang and Elasticsearch."}
+	_, err = client.Index().Index(testIndexName).Type("doc").Id("1").BodyJson(&tweet1).Refresh("false").Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The document must not be visible yet
+	count, err := client.Count(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected Count = %d before refresh; got %d", 0, count)
+	}
+
+	// Refresh the index
+	res, err := client.Refresh(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected result; got nil")
+	}
+
+	// The document must be visible now
+	searchResult, err := client.Search(testIndexName).Query(NewTermQuery("user", "olivere")).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(1), searchResult.TotalHits(); want != have {
+		t.Fatalf("expected TotalHits = %d after refresh; got %d", want, have)
+	}
+}
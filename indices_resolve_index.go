@@ -0,0 +1,147 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olivere/elastic/uritemplates"
+)
+
+// IndicesResolveIndexService resolves the specified names and/or index
+// patterns for indices, aliases, and data streams. It is supported as
+// of Elasticsearch 7.9.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.9/indices-resolve-index-api.html
+// for more details.
+type IndicesResolveIndexService struct {
+	client          *Client
+	pretty          bool
+	name            []string
+	expandWildcards string
+}
+
+// NewIndicesResolveIndexService creates a new IndicesResolveIndexService.
+func NewIndicesResolveIndexService(client *Client) *IndicesResolveIndexService {
+	return &IndicesResolveIndexService{
+		client: client,
+	}
+}
+
+// Name is a list of names or wildcard expressions of indices, aliases,
+// and data streams to resolve.
+func (s *IndicesResolveIndexService) Name(name ...string) *IndicesResolveIndexService {
+	s.name = append(s.name, name...)
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression
+// to concrete indices that are open, closed or both.
+// Options: open, closed, hidden, none, all. Default: open.
+func (s *IndicesResolveIndexService) ExpandWildcards(expandWildcards string) *IndicesResolveIndexService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *IndicesResolveIndexService) Pretty(pretty bool) *IndicesResolveIndexService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *IndicesResolveIndexService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_resolve/index/{name}", map[string]string{
+		"name": strings.Join(s.name, ","),
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *IndicesResolveIndexService) Validate() error {
+	var invalid []string
+	if len(s.name) == 0 {
+		invalid = append(invalid, "Name")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *IndicesResolveIndexService) Do(ctx context.Context) (*IndicesResolveIndexResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "GET",
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(IndicesResolveIndexResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// IndicesResolveIndexResponse is the response of IndicesResolveIndexService.Do.
+type IndicesResolveIndexResponse struct {
+	Indices     []*IndicesResolveIndexIndex      `json:"indices"`
+	Aliases     []*IndicesResolveIndexAlias      `json:"aliases"`
+	DataStreams []*IndicesResolveIndexDataStream `json:"data_streams"`
+}
+
+// IndicesResolveIndexIndex is a concrete index in IndicesResolveIndexResponse.
+type IndicesResolveIndexIndex struct {
+	Name       string   `json:"name"`
+	Aliases    []string `json:"aliases,omitempty"`
+	Attributes []string `json:"attributes,omitempty"`
+	DataStream string   `json:"data_stream,omitempty"`
+}
+
+// IndicesResolveIndexAlias is an alias in IndicesResolveIndexResponse.
+type IndicesResolveIndexAlias struct {
+	Name    string   `json:"name"`
+	Indices []string `json:"indices,omitempty"`
+}
+
+// IndicesResolveIndexDataStream is a data stream in IndicesResolveIndexResponse.
+type IndicesResolveIndexDataStream struct {
+	Name           string   `json:"name"`
+	BackingIndices []string `json:"backing_indices,omitempty"`
+	TimestampField string   `json:"timestamp_field,omitempty"`
+}
@@ -0,0 +1,209 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIndicesResolveIndexBuildURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Names           []string
+		ExpandWildcards string
+		Expected        string
+		ExpectedParams  string
+	}{
+		{
+			[]string{"elastic-*"},
+			"",
+			"/_resolve/index/elastic-%2A",
+			"",
+		},
+		{
+			[]string{"elastic-test", "logs-*"},
+			"all",
+			"/_resolve/index/elastic-test%2Clogs-%2A",
+			"expand_wildcards=all",
+		},
+	}
+
+	for i, test := range tests {
+		path, params, err := client.ResolveIndex(test.Names...).ExpandWildcards(test.ExpandWildcards).buildURL()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		if path != test.Expected {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, test.Expected, path)
+		}
+		if want, have := test.ExpectedParams, params.Encode(); want != have {
+			t.Errorf("case #%d: expected params %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestIndicesResolveIndexValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, err := client.ResolveIndex().Do(context.TODO())
+	if err == nil {
+		t.Fatal("expected error; got: nil")
+	}
+	if want, have := "missing required fields: [Name]", err.Error(); want != have {
+		t.Fatalf("expected error %q; got: %q", want, have)
+	}
+}
+
+func TestIndicesResolveIndexResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want, have := "/_resolve/index/elastic-%2A", r.URL.EscapedPath(); want != have {
+			http.Error(w, fmt.Sprintf("expected path %q; got: %q", want, have), http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{
+			"indices": [
+				{"name":"elastic-test","aliases":["elastic-alias"],"attributes":["open"]},
+				{"name":".ds-elastic-ds-000001","attributes":["hidden","open"],"data_stream":"elastic-ds"}
+			],
+			"aliases": [
+				{"name":"elastic-alias","indices":["elastic-test"]}
+			],
+			"data_streams": [
+				{"name":"elastic-ds","backing_indices":[".ds-elastic-ds-000001"],"timestamp_field":"@timestamp"}
+			]
+		}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.ResolveIndex("elastic-*").Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response; got: nil")
+	}
+	if want, have := 2, len(res.Indices); want != have {
+		t.Fatalf("expected %d indices; got: %d", want, have)
+	}
+	if want, have := "elastic-test", res.Indices[0].Name; want != have {
+		t.Errorf("expected index %q; got: %q", want, have)
+	}
+	if want, have := []string{"elastic-alias"}, res.Indices[0].Aliases; len(have) != 1 || want[0] != have[0] {
+		t.Errorf("expected aliases %v; got: %v", want, have)
+	}
+	if want, have := "elastic-ds", res.Indices[1].DataStream; want != have {
+		t.Errorf("expected data stream %q; got: %q", want, have)
+	}
+	if want, have := 1, len(res.Aliases); want != have {
+		t.Fatalf("expected %d aliases; got: %d", want, have)
+	}
+	if want, have := "elastic-alias", res.Aliases[0].Name; want != have {
+		t.Errorf("expected alias %q; got: %q", want, have)
+	}
+	if want, have := 1, len(res.DataStreams); want != have {
+		t.Fatalf("expected %d data streams; got: %d", want, have)
+	}
+	if want, have := "@timestamp", res.DataStreams[0].TimestampField; want != have {
+		t.Errorf("expected timestamp field %q; got: %q", want, have)
+	}
+}
+
+func TestIndicesResolveIndexIntegration(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	esversion, err := client.ElasticsearchVersion(DefaultURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if esversion < "7.9.0" {
+		t.Skipf("Resolve Index API is available since 7.9; got %s", esversion)
+	}
+
+	const (
+		aliasName      = "elastic-test-resolve-alias"
+		templateName   = "elastic-test-resolve-template"
+		dataStreamName = "elastic-test-resolve-ds"
+	)
+
+	// Create an alias
+	if _, err := client.Alias().Add(testIndexName, aliasName).Do(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Create a data stream via an index template
+	_, err = client.PerformRequest(context.TODO(), PerformRequestOptions{
+		Method: "PUT",
+		Path:   "/_index_template/" + templateName,
+		Body: map[string]interface{}{
+			"index_patterns": []string{dataStreamName + "*"},
+			"data_stream":    map[string]interface{}{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.PerformRequest(context.TODO(), PerformRequestOptions{
+		Method: "DELETE",
+		Path:   "/_index_template/" + templateName,
+	})
+	_, err = client.PerformRequest(context.TODO(), PerformRequestOptions{
+		Method: "PUT",
+		Path:   "/_data_stream/" + dataStreamName,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.PerformRequest(context.TODO(), PerformRequestOptions{
+		Method: "DELETE",
+		Path:   "/_data_stream/" + dataStreamName,
+	})
+
+	// Resolve a wildcard
+	res, err := client.ResolveIndex("elastic-test*").ExpandWildcards("all").Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response; got: nil")
+	}
+
+	var foundIndex bool
+	for _, index := range res.Indices {
+		if index.Name == testIndexName {
+			foundIndex = true
+		}
+	}
+	if !foundIndex {
+		t.Errorf("expected to find index %q in %+v", testIndexName, res.Indices)
+	}
+	var foundAlias bool
+	for _, alias := range res.Aliases {
+		if alias.Name == aliasName {
+			foundAlias = true
+		}
+	}
+	if !foundAlias {
+		t.Errorf("expected to find alias %q in %+v", aliasName, res.Aliases)
+	}
+	var foundDataStream bool
+	for _, ds := range res.DataStreams {
+		if ds.Name == dataStreamName {
+			foundDataStream = true
+		}
+	}
+	if !foundDataStream {
+		t.Errorf("expected to find data stream %q in %+v", dataStreamName, res.DataStreams)
+	}
+}
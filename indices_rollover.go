@@ -112,6 +112,14 @@ func (s *IndicesRolloverService) AddMaxIndexDocsCondition(docs int64) *IndicesRo
 	return s
 }
 
+// AddMaxIndexSizeCondition adds a condition to set the max size of the
+// primary shards of the index, e.g. "50gb". It is supported as of
+// Elasticsearch 6.1.
+func (s *IndicesRolloverService) AddMaxIndexSizeCondition(size string) *IndicesRolloverService {
+	s.conditions["max_size"] = size
+	return s
+}
+
 // Settings adds the index settings.
 func (s *IndicesRolloverService) Settings(settings map[string]interface{}) *IndicesRolloverService {
 	s.settings = settings
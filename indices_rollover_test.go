@@ -5,7 +5,11 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -91,6 +95,21 @@ func TestIndicesRolloverBodyAddPredefinedConditions(t *testing.T) {
 	}
 }
 
+func TestIndicesRolloverBodyAddMaxIndexSizeCondition(t *testing.T) {
+	client := setupTestClient(t)
+	svc := NewIndicesRolloverService(client).
+		AddMaxIndexSizeCondition("5gb")
+	data, err := json.Marshal(svc.getBody())
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"conditions":{"max_size":"5gb"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestIndicesRolloverBodyComplex(t *testing.T) {
 	client := setupTestClient(t)
 	svc := NewIndicesRolloverService(client).
@@ -114,3 +133,70 @@ func TestIndicesRolloverBodyComplex(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestIndicesRolloverResponse(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if want, have := "POST", r.Method; want != have {
+			t.Errorf("expected method %s; got: %s", want, have)
+		}
+		if want, have := "/logs_write/_rollover", r.URL.Path; want != have {
+			t.Errorf("expected path %s; got: %s", want, have)
+		}
+		if want, have := "true", r.URL.Query().Get("dry_run"); want != have {
+			t.Errorf("expected dry_run=%s; got: %s", want, have)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{
+			"acknowledged": false,
+			"shards_acknowledged": false,
+			"old_index": "logs-000001",
+			"new_index": "logs-000002",
+			"rolled_over": false,
+			"dry_run": true,
+			"conditions": {
+				"[max_age: 7d]": false,
+				"[max_docs: 1000]": true,
+				"[max_size: 5gb]": false
+			}
+		}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := client.RolloverIndex("logs_write").
+		AddMaxIndexAgeCondition("7d").
+		AddMaxIndexDocsCondition(1000).
+		AddMaxIndexSizeCondition("5gb").
+		DryRun(true).
+		Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response; got: nil")
+	}
+	if want, have := "logs-000001", res.OldIndex; want != have {
+		t.Errorf("expected old index %q; got: %q", want, have)
+	}
+	if want, have := "logs-000002", res.NewIndex; want != have {
+		t.Errorf("expected new index %q; got: %q", want, have)
+	}
+	if res.RolledOver {
+		t.Errorf("expected rolled over = %v; got: %v", false, res.RolledOver)
+	}
+	if !res.DryRun {
+		t.Errorf("expected dry run = %v; got: %v", true, res.DryRun)
+	}
+	if want, have := 3, len(res.Conditions); want != have {
+		t.Fatalf("expected %d conditions; got: %d", want, have)
+	}
+	if !res.Conditions["[max_docs: 1000]"] {
+		t.Errorf("expected max_docs condition to be met; got: %v", res.Conditions)
+	}
+	if res.Conditions["[max_age: 7d]"] {
+		t.Errorf("expected max_age condition not to be met; got: %v", res.Conditions)
+	}
+}
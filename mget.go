@@ -216,6 +216,13 @@ func (item *MultiGetItem) FetchSource(fetchSourceContext *FetchSourceContext) *M
 	return item
 }
 
+// FetchSourceContext specifies source filtering for this item only,
+// e.g. to return a different set of fields for each document.
+// It is an alias of FetchSource.
+func (item *MultiGetItem) FetchSourceContext(fetchSourceContext *FetchSourceContext) *MultiGetItem {
+	return item.FetchSource(fetchSourceContext)
+}
+
 // Source returns the serialized JSON to be sent to Elasticsearch as
 // part of a MultiGet search.
 func (item *MultiGetItem) Source() (interface{}, error) {
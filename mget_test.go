@@ -94,3 +94,27 @@ func TestMultiGet(t *testing.T) {
 		t.Errorf("expected Message of second tweet to be %q; got %q", tweet3.Message, doc.Message)
 	}
 }
+
+func TestMultiGetSourceWithPerItemFetchSourceContext(t *testing.T) {
+	client := setupTestClient(t)
+
+	svc := client.MultiGet().
+		Add(NewMultiGetItem().Index(testIndexName).Type("doc").Id("1").
+			FetchSourceContext(NewFetchSourceContext(true).Include("user", "message"))).
+		Add(NewMultiGetItem().Index(testIndexName).Type("doc").Id("2")).
+		Add(NewMultiGetItem().Index(testIndexName).Type("doc").Id("3").
+			FetchSourceContext(NewFetchSourceContext(true).Exclude("retweets")))
+	src, err := svc.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"docs":[{"_id":"1","_index":"elastic-test","_source":{"includes":["user","message"]},"_type":"doc"},{"_id":"2","_index":"elastic-test","_type":"doc"},{"_id":"3","_index":"elastic-test","_source":{"excludes":["retweets"]},"_type":"doc"}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinimumShouldMatchBuilder builds a value for the minimum_should_match
+// parameter, e.g. "3", "75%", "3<90%", or "2<-25% 9<-3".
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-minimum-should-match.html
+// for details.
+type MinimumShouldMatchBuilder struct {
+	value        string
+	combinations []minimumShouldMatchCombination
+}
+
+type minimumShouldMatchCombination struct {
+	lowThreshold int
+	spec         string
+}
+
+// NewMinimumShouldMatchBuilder creates a new MinimumShouldMatchBuilder.
+func NewMinimumShouldMatchBuilder() *MinimumShouldMatchBuilder {
+	return &MinimumShouldMatchBuilder{}
+}
+
+// FixedValue sets a fixed number of optional clauses that must match.
+// A negative value indicates the number of optional clauses that may
+// be missing.
+func (b *MinimumShouldMatchBuilder) FixedValue(n int) *MinimumShouldMatchBuilder {
+	b.value = strconv.Itoa(n)
+	return b
+}
+
+// Percent sets the percentage of optional clauses that must match.
+// A negative percentage indicates the percentage of optional clauses
+// that may be missing.
+func (b *MinimumShouldMatchBuilder) Percent(percent int) *MinimumShouldMatchBuilder {
+	b.value = strconv.Itoa(percent) + "%"
+	return b
+}
+
+// Combination adds a conditional specification: If the number of optional
+// clauses is less than or equal to lowThreshold, all of them are required.
+// Otherwise spec applies, which must be a fixed value or a percentage,
+// e.g. "90%" or "-2". Combinations can be added multiple times with
+// increasing thresholds.
+func (b *MinimumShouldMatchBuilder) Combination(lowThreshold int, spec string) *MinimumShouldMatchBuilder {
+	b.combinations = append(b.combinations, minimumShouldMatchCombination{
+		lowThreshold: lowThreshold,
+		spec:         spec,
+	})
+	return b
+}
+
+// Build returns the minimum_should_match specification or an error if
+// the specification is invalid.
+func (b *MinimumShouldMatchBuilder) Build() (string, error) {
+	if len(b.combinations) == 0 {
+		if b.value == "" {
+			return "", fmt.Errorf("minimum_should_match: no value specified")
+		}
+		return b.value, nil
+	}
+	if b.value != "" {
+		return "", fmt.Errorf("minimum_should_match: cannot use a fixed value or percentage together with combinations")
+	}
+	parts := make([]string, 0, len(b.combinations))
+	for i, c := range b.combinations {
+		if c.lowThreshold < 0 {
+			return "", fmt.Errorf("minimum_should_match: threshold %d must not be negative", c.lowThreshold)
+		}
+		if i > 0 && c.lowThreshold <= b.combinations[i-1].lowThreshold {
+			return "", fmt.Errorf("minimum_should_match: thresholds must be increasing, got %d after %d", c.lowThreshold, b.combinations[i-1].lowThreshold)
+		}
+		if !isMinimumShouldMatchValue(c.spec) {
+			return "", fmt.Errorf("minimum_should_match: invalid specification %q for threshold %d", c.spec, c.lowThreshold)
+		}
+		parts = append(parts, fmt.Sprintf("%d<%s", c.lowThreshold, c.spec))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// String returns the minimum_should_match specification. It returns an
+// empty string if the specification is invalid; use Build to get the error.
+func (b *MinimumShouldMatchBuilder) String() string {
+	spec, err := b.Build()
+	if err != nil {
+		return ""
+	}
+	return spec
+}
+
+// isMinimumShouldMatchValue returns true if s is a (possibly negative)
+// integer or percentage.
+func isMinimumShouldMatchValue(s string) bool {
+	s = strings.TrimSuffix(s, "%")
+	_, err := strconv.Atoi(s)
+	return err == nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+)
+
+func TestMinimumShouldMatchBuilder(t *testing.T) {
+	tests := []struct {
+		Builder  *MinimumShouldMatchBuilder
+		Expected string
+	}{
+		// #0
+		{NewMinimumShouldMatchBuilder().FixedValue(3), "3"},
+		// #1
+		{NewMinimumShouldMatchBuilder().FixedValue(-2), "-2"},
+		// #2
+		{NewMinimumShouldMatchBuilder().Percent(75), "75%"},
+		// #3
+		{NewMinimumShouldMatchBuilder().Percent(-25), "-25%"},
+		// #4
+		{NewMinimumShouldMatchBuilder().Combination(3, "90%"), "3<90%"},
+		// #5
+		{NewMinimumShouldMatchBuilder().Combination(2, "-25%").Combination(9, "-3"), "2<-25% 9<-3"},
+	}
+
+	for i, test := range tests {
+		got, err := test.Builder.Build()
+		if err != nil {
+			t.Fatalf("case #%d: expected no error, got: %v", i, err)
+		}
+		if got != test.Expected {
+			t.Errorf("case #%d: expected %q, got: %q", i, test.Expected, got)
+		}
+		if got := test.Builder.String(); got != test.Expected {
+			t.Errorf("case #%d: expected String() = %q, got: %q", i, test.Expected, got)
+		}
+	}
+}
+
+func TestMinimumShouldMatchBuilderInvalid(t *testing.T) {
+	tests := []*MinimumShouldMatchBuilder{
+		// #0 no value
+		NewMinimumShouldMatchBuilder(),
+		// #1 value and combination
+		NewMinimumShouldMatchBuilder().FixedValue(1).Combination(3, "90%"),
+		// #2 invalid spec
+		NewMinimumShouldMatchBuilder().Combination(3, "ninety"),
+		// #3 decreasing thresholds
+		NewMinimumShouldMatchBuilder().Combination(9, "-3").Combination(2, "-25%"),
+		// #4 negative threshold
+		NewMinimumShouldMatchBuilder().Combination(-1, "50%"),
+	}
+
+	for i, builder := range tests {
+		if _, err := builder.Build(); err == nil {
+			t.Errorf("case #%d: expected error", i)
+		}
+		if got := builder.String(); got != "" {
+			t.Errorf("case #%d: expected String() to be empty, got: %q", i, got)
+		}
+	}
+}
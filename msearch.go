@@ -111,6 +111,9 @@ func (s *MultiSearchService) Do(ctx context.Context) (*MultiSearchResult, error)
 }
 
 // MultiSearchResult is the outcome of running a multi-search operation.
+// Every response reports its own search time and whether it timed out,
+// see SearchResult.TookInMillis and SearchResult.TimedOut.
 type MultiSearchResult struct {
-	Responses []*SearchResult `json:"responses,omitempty"`
+	TookInMillis int64           `json:"took,omitempty"` // total search time in milliseconds (as of Elasticsearch 7.0)
+	Responses    []*SearchResult `json:"responses,omitempty"`
 }
@@ -301,3 +301,48 @@ func TestMultiSearchWithOneRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiSearchResultTookAndTimedOut(t *testing.T) {
+	js := `{
+  "took": 25,
+  "responses": [
+    {
+      "took": 12,
+      "timed_out": false,
+      "_shards": { "total": 1, "successful": 1, "skipped": 0, "failed": 0 },
+      "hits": { "total": 2, "max_score": 1.0, "hits": [] },
+      "status": 200
+    },
+    {
+      "took": 20,
+      "timed_out": true,
+      "_shards": { "total": 1, "successful": 1, "skipped": 0, "failed": 0 },
+      "hits": { "total": 0, "max_score": null, "hits": [] },
+      "status": 200
+    }
+  ]
+}`
+
+	var res MultiSearchResult
+	if err := json.Unmarshal([]byte(js), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(25), res.TookInMillis; want != have {
+		t.Errorf("expected took = %d; got: %d", want, have)
+	}
+	if want, have := 2, len(res.Responses); want != have {
+		t.Fatalf("expected %d responses; got: %d", want, have)
+	}
+	if want, have := int64(12), res.Responses[0].TookInMillis; want != have {
+		t.Errorf("expected took = %d in response #0; got: %d", want, have)
+	}
+	if res.Responses[0].TimedOut {
+		t.Errorf("expected timed_out = %v in response #0; got: %v", false, res.Responses[0].TimedOut)
+	}
+	if want, have := int64(20), res.Responses[1].TookInMillis; want != have {
+		t.Errorf("expected took = %d in response #1; got: %d", want, have)
+	}
+	if !res.Responses[1].TimedOut {
+		t.Errorf("expected timed_out = %v in response #1; got: %v", true, res.Responses[1].TimedOut)
+	}
+}
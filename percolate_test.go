@@ -6,6 +6,11 @@ package elastic
 
 import (
 	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -66,3 +71,95 @@ func TestPercolate(t *testing.T) {
 		t.Fatalf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestRegisterPercolatorQuery(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t)
+
+	// Create query index
+	_, err := client.CreateIndex(testQueryIndex).Body(testQueryMapping).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Register a term query
+	svc, err := client.RegisterPercolatorQuery(testQueryIndex, "1", NewTermQuery("message", "bonsai"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := svc.Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response != nil; got nil")
+	}
+	if want, have := "1", res.Id; want != have {
+		t.Fatalf("expected Id = %q; got %q", want, have)
+	}
+	_, err = client.Refresh(testQueryIndex).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Percolate a matching document
+	pq := NewPercolatorQuery().
+		Field("query").
+		DocumentType("doc").
+		Document(doctype{Message: "A new bonsai tree in the office"})
+	searchResult, err := client.Search(testQueryIndex).Type("doc").Query(pq).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searchResult.Hits == nil {
+		t.Fatal("expected SearchResult.Hits != nil; got nil")
+	}
+	if got, want := searchResult.Hits.TotalHits, int64(1); got != want {
+		t.Fatalf("expected SearchResult.Hits.TotalHits = %d; got %d", want, got)
+	}
+	got := string(*searchResult.Hits.Hits[0].Source)
+	expected := `{"query":{"term":{"message":"bonsai"}}}`
+	if got != expected {
+		t.Fatalf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRegisterPercolatorQueryWithType(t *testing.T) {
+	var path, refresh, body string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		path, refresh, body = r.URL.Path, r.URL.Query().Get("refresh"), strings.TrimSpace(string(data))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintln(w, `{"_index":"elastic-queries","_type":"_doc","_id":"1","_version":1,"result":"created"}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := client.RegisterPercolatorQuery(testQueryIndex, "1", NewTermQuery("message", "bonsai"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Type("_doc").Refresh("true").Do(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/"+testQueryIndex+"/_doc/1", path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+	if want, have := "true", refresh; want != have {
+		t.Errorf("expected refresh %q; got: %q", want, have)
+	}
+	if want, have := `{"query":{"term":{"message":"bonsai"}}}`, body; want != have {
+		t.Errorf("expected body\n%s\ngot:\n%s", want, have)
+	}
+
+	// A query is required
+	if _, err := client.RegisterPercolatorQuery(testQueryIndex, "1", nil); err == nil {
+		t.Fatal("expected error when query is missing")
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/olivere/elastic/uritemplates"
+)
+
+const (
+	// DefaultPointInTimeKeepAlive is the default time a point in time
+	// will be kept alive.
+	DefaultPointInTimeKeepAlive = "1m"
+)
+
+// PointInTimeService opens and closes point in time (PIT) readers.
+// A point in time preserves the state of one or more indices and is
+// the recommended way to page deeply through search results with
+// search_after. It is supported as of Elasticsearch 7.10.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.10/point-in-time-api.html
+// for details.
+type PointInTimeService struct {
+	client            *Client
+	pretty            bool
+	keepAlive         string
+	routing           string
+	preference        string
+	ignoreUnavailable *bool
+	expandWildcards   string
+}
+
+// NewPointInTimeService creates a new PointInTimeService.
+func NewPointInTimeService(client *Client) *PointInTimeService {
+	return &PointInTimeService{
+		client:    client,
+		keepAlive: DefaultPointInTimeKeepAlive,
+	}
+}
+
+// KeepAlive specifies how long the point in time should be kept alive
+// after it has been opened, e.g. "1m". It is "1m" by default.
+func (s *PointInTimeService) KeepAlive(keepAlive string) *PointInTimeService {
+	s.keepAlive = keepAlive
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the point in time is opened on.
+func (s *PointInTimeService) Routing(routings ...string) *PointInTimeService {
+	s.routing = strings.Join(routings, ",")
+	return s
+}
+
+// Preference specifies the node or shard the operation should be
+// performed on (default: random).
+func (s *PointInTimeService) Preference(preference string) *PointInTimeService {
+	s.preference = preference
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *PointInTimeService) IgnoreUnavailable(ignoreUnavailable bool) *PointInTimeService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *PointInTimeService) ExpandWildcards(expandWildcards string) *PointInTimeService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *PointInTimeService) Pretty(pretty bool) *PointInTimeService {
+	s.pretty = pretty
+	return s
+}
+
+// buildOpenURL builds the URL for opening a point in time.
+func (s *PointInTimeService) buildOpenURL(indices []string) (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/{index}/_pit", map[string]string{
+		"index": strings.Join(indices, ","),
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.keepAlive != "" {
+		params.Set("keep_alive", s.keepAlive)
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprintf("%v", *s.ignoreUnavailable))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Open opens a point in time on the given indices and returns its id.
+// Pass the id to SearchService.PointInTime to search it.
+func (s *PointInTimeService) Open(ctx context.Context, indices ...string) (string, error) {
+	// Check pre-conditions
+	if len(indices) == 0 {
+		return "", errors.New("missing required fields: [Index]")
+	}
+
+	// Get URL for request
+	path, params, err := s.buildOpenURL(indices)
+	if err != nil {
+		return "", err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// Return operation response
+	ret := new(OpenPointInTimeResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return "", err
+	}
+	return ret.Id, nil
+}
+
+// Close closes the point in time with the given id, releasing
+// its resources in the cluster.
+func (s *PointInTimeService) Close(ctx context.Context, id string) (*ClosePointInTimeResponse, error) {
+	// Check pre-conditions
+	if id == "" {
+		return nil, errors.New("missing required fields: [Id]")
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+
+	// Setup HTTP request body
+	body := map[string]interface{}{
+		"id": id,
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "DELETE",
+		Path:   "/_pit",
+		Params: params,
+		Body:   body,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(ClosePointInTimeResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+// OpenPointInTimeResponse is the result of opening a point in time.
+type OpenPointInTimeResponse struct {
+	Id string `json:"id"`
+}
+
+// ClosePointInTimeResponse is the result of closing a point in time.
+type ClosePointInTimeResponse struct {
+	Succeeded bool `json:"succeeded"`
+	NumFreed  int  `json:"num_freed"`
+}
+
+// -- PointInTime --
+
+// PointInTime specifies a point in time to search, see SearchSource.PointInTime.
+type PointInTime struct {
+	Id        string
+	KeepAlive string
+}
+
+// NewPointInTime creates a new PointInTime with the given id and keep-alive.
+func NewPointInTime(id, keepAlive string) *PointInTime {
+	return &PointInTime{
+		Id:        id,
+		KeepAlive: keepAlive,
+	}
+}
+
+// Source returns the JSON-serializable data.
+func (pit *PointInTime) Source() (interface{}, error) {
+	if pit == nil {
+		return nil, nil
+	}
+	source := map[string]interface{}{
+		"id": pit.Id,
+	}
+	if pit.KeepAlive != "" {
+		source["keep_alive"] = pit.KeepAlive
+	}
+	return source, nil
+}
@@ -0,0 +1,204 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestPointInTimeBuildOpenURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Indices  []string
+		Service  *PointInTimeService
+		Expected string
+	}{
+		{
+			[]string{"index1"},
+			client.PointInTime(),
+			"/index1/_pit?keep_alive=1m",
+		},
+		{
+			[]string{"index1", "index2"},
+			client.PointInTime().KeepAlive("5m"),
+			"/index1%2Cindex2/_pit?keep_alive=5m",
+		},
+		{
+			[]string{"index1"},
+			client.PointInTime().Routing("a", "b").Preference("_local"),
+			"/index1/_pit?keep_alive=1m&preference=_local&routing=a%2Cb",
+		},
+	}
+
+	for i, test := range tests {
+		path, params, err := test.Service.buildOpenURL(test.Indices)
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if want, have := test.Expected, path+"?"+params.Encode(); want != have {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestPointInTimeOpenAndClose(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/"+testIndexName+"/_pit":
+			if want, have := "2m", r.URL.Query().Get("keep_alive"); want != have {
+				t.Errorf("expected keep_alive=%q; got %q", want, have)
+			}
+			fmt.Fprintln(w, `{"id":"pit-1"}`)
+		case r.Method == "DELETE" && r.URL.Path == "/_pit":
+			var body struct {
+				Id string `json:"id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("expected to decode close body; got %v", err)
+			}
+			if want, have := "pit-1", body.Id; want != have {
+				t.Errorf("expected id %q; got %q", want, have)
+			}
+			fmt.Fprintln(w, `{"succeeded":true,"num_freed":1}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := client.PointInTime().KeepAlive("2m").Open(context.TODO(), testIndexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "pit-1", id; want != have {
+		t.Fatalf("expected id %q; got %q", want, have)
+	}
+
+	res, err := client.PointInTime().Close(context.TODO(), id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response != nil")
+	}
+	if !res.Succeeded {
+		t.Errorf("expected succeeded = true")
+	}
+	if want, have := 1, res.NumFreed; want != have {
+		t.Errorf("expected num_freed = %d; got %d", want, have)
+	}
+}
+
+func TestPointInTimeValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	if _, err := client.PointInTime().Open(context.TODO()); err == nil {
+		t.Errorf("expected error when opening without indices")
+	}
+	if _, err := client.PointInTime().Close(context.TODO(), ""); err == nil {
+		t.Errorf("expected error when closing without id")
+	}
+}
+
+func TestPointInTimeSearchAfter(t *testing.T) {
+	var searches int
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/"+testIndexName+"/_pit":
+			fmt.Fprintln(w, `{"id":"pit-1"}`)
+		case r.URL.Path == "/_search":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("expected to decode search body; got %v", err)
+			}
+			searches++
+			switch searches {
+			case 1:
+				pit, _ := body["pit"].(map[string]interface{})
+				if want, have := "pit-1", pit["id"]; want != have {
+					t.Errorf("expected pit id %q; got %v", want, have)
+				}
+				if want, have := "1m", pit["keep_alive"]; want != have {
+					t.Errorf("expected pit keep_alive %q; got %v", want, have)
+				}
+				if _, found := body["search_after"]; found {
+					t.Errorf("expected no search_after on first page; got %v", body)
+				}
+				fmt.Fprintln(w, `{"pit_id":"pit-2","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1","sort":[1,"a"]}]}}`)
+			case 2:
+				pit, _ := body["pit"].(map[string]interface{})
+				if want, have := "pit-2", pit["id"]; want != have {
+					t.Errorf("expected pit id %q; got %v", want, have)
+				}
+				after, _ := body["search_after"].([]interface{})
+				if len(after) != 2 || after[1] != "a" {
+					t.Errorf("expected search_after values [1 a]; got %v", after)
+				}
+				fmt.Fprintln(w, `{"pit_id":"pit-2","hits":{"total":2,"hits":[]}}`)
+			default:
+				t.Errorf("unexpected search request #%d", searches)
+			}
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id, err := client.PointInTime().Open(context.TODO(), testIndexName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		after []interface{}
+		docs  int
+	)
+	for {
+		svc := client.Search().PointInTime(id, "1m").Sort("created", true).Sort("_id", true)
+		if after != nil {
+			svc = svc.SearchAfter(after...)
+		}
+		res, err := svc.Do(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := len(res.Hits.Hits)
+		if n == 0 {
+			break
+		}
+		docs += n
+		after = res.Hits.Hits[n-1].Sort
+		if res.PitId != "" {
+			id = res.PitId
+		}
+	}
+	if want, have := 1, docs; want != have {
+		t.Fatalf("expected %d hits; got %d", want, have)
+	}
+	if want, have := 2, searches; want != have {
+		t.Fatalf("expected %d search requests; got %d", want, have)
+	}
+}
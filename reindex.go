@@ -27,6 +27,7 @@ type ReindexService struct {
 	destination         *ReindexDestination
 	conflicts           string
 	size                *int
+	maxDocs             *int
 	script              *Script
 }
 
@@ -63,6 +64,17 @@ func (s *ReindexService) Slices(slices interface{}) *ReindexService {
 	return s
 }
 
+// SliceAuto enables automatic slicing, i.e. Elasticsearch parallelizes the
+// reindex process into n slices on its own. If n is zero or negative,
+// Elasticsearch picks the number of slices itself ("auto"). Notice that
+// Elasticsearch uses at most as many slices as the source index has shards.
+func (s *ReindexService) SliceAuto(n int) *ReindexService {
+	if n <= 0 {
+		return s.Slices("auto")
+	}
+	return s.Slices(n)
+}
+
 // Refresh indicates whether Elasticsearch should refresh the effected indexes
 // immediately.
 //
@@ -156,11 +168,20 @@ func (s *ReindexService) ProceedOnVersionConflict() *ReindexService {
 }
 
 // Size sets an upper limit for the number of processed documents.
+//
+// Deprecated: Use MaxDocs as of Elasticsearch 7.3.
 func (s *ReindexService) Size(size int) *ReindexService {
 	s.size = &size
 	return s
 }
 
+// MaxDocs sets an upper limit for the number of processed documents.
+// It replaces Size as of Elasticsearch 7.3.
+func (s *ReindexService) MaxDocs(maxDocs int) *ReindexService {
+	s.maxDocs = &maxDocs
+	return s
+}
+
 // Script allows for modification of the documents as they are reindexed
 // from source to destination.
 func (s *ReindexService) Script(script *Script) *ReindexService {
@@ -242,6 +263,9 @@ func (s *ReindexService) getBody() (interface{}, error) {
 	if s.size != nil {
 		body["size"] = *s.size
 	}
+	if s.maxDocs != nil {
+		body["max_docs"] = *s.maxDocs
+	}
 	if s.script != nil {
 		out, err := s.script.Source()
 		if err != nil {
@@ -350,6 +374,19 @@ func (s *ReindexService) DoAsync(ctx context.Context) (*StartTaskResult, error)
 	return ret, nil
 }
 
+// DoSlicedAsync starts the reindexing operation as a single task that is
+// automatically divided into n slices (see SliceAuto) and returns the id
+// of the task. Use the id with TasksGetTaskService to watch the outcome
+// of all slices. This works even if Elasticsearch uses less than n slices,
+// e.g. because the source index has less than n shards.
+func (s *ReindexService) DoSlicedAsync(ctx context.Context, n int) (string, error) {
+	res, err := s.SliceAuto(n).DoAsync(ctx)
+	if err != nil {
+		return "", err
+	}
+	return res.TaskId, nil
+}
+
 // -- Source of Reindex --
 
 // ReindexSource specifies the source of a Reindex process.
@@ -0,0 +1,119 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/olivere/elastic/uritemplates"
+)
+
+// ReindexRethrottleService changes the throttle of a running reindex task.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/docs-reindex.html#docs-reindex-rethrottle
+// for details.
+type ReindexRethrottleService struct {
+	client            *Client
+	pretty            bool
+	taskId            string
+	requestsPerSecond *float64
+}
+
+// NewReindexRethrottleService creates a new ReindexRethrottleService.
+func NewReindexRethrottleService(client *Client) *ReindexRethrottleService {
+	return &ReindexRethrottleService{
+		client: client,
+	}
+}
+
+// TaskId specifies the reindex task to change the throttle of,
+// in the format node_id:task_number.
+func (s *ReindexRethrottleService) TaskId(taskId string) *ReindexRethrottleService {
+	s.taskId = taskId
+	return s
+}
+
+// RequestsPerSecond specifies the new throttle in sub-requests per second.
+// Use -1 to disable throttling.
+func (s *ReindexRethrottleService) RequestsPerSecond(requestsPerSecond float64) *ReindexRethrottleService {
+	s.requestsPerSecond = &requestsPerSecond
+	return s
+}
+
+// Pretty indicates that the JSON response be indented and human readable.
+func (s *ReindexRethrottleService) Pretty(pretty bool) *ReindexRethrottleService {
+	s.pretty = pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *ReindexRethrottleService) buildURL() (string, url.Values, error) {
+	// Build URL
+	path, err := uritemplates.Expand("/_reindex/{task_id}/_rethrottle", map[string]string{
+		"task_id": s.taskId,
+	})
+	if err != nil {
+		return "", url.Values{}, err
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.pretty {
+		params.Set("pretty", "true")
+	}
+	if s.requestsPerSecond != nil {
+		params.Set("requests_per_second", strconv.FormatFloat(*s.requestsPerSecond, 'f', -1, 64))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *ReindexRethrottleService) Validate() error {
+	var invalid []string
+	if s.taskId == "" {
+		invalid = append(invalid, "TaskId")
+	}
+	if s.requestsPerSecond == nil {
+		invalid = append(invalid, "RequestsPerSecond")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Do executes the operation.
+func (s *ReindexRethrottleService) Do(ctx context.Context) (*TasksListResponse, error) {
+	// Check pre-conditions
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Get URL for request
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+
+	// Get HTTP response
+	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+		Method: "POST",
+		Path:   path,
+		Params: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Return operation response
+	ret := new(TasksListResponse)
+	if err := s.client.decoder.Decode(res.Body, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
@@ -7,6 +7,9 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -221,6 +224,25 @@ func TestReindexSourceWithSourceAndSize(t *testing.T) {
 	}
 }
 
+func TestReindexSourceWithSourceAndMaxDocs(t *testing.T) {
+	client := setupTestClient(t)
+	src := NewReindexSource().Index("twitter")
+	dst := NewReindexDestination().Index("new_twitter")
+	out, err := client.Reindex().MaxDocs(100).Source(src).Destination(dst).getBody()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(b)
+	want := `{"dest":{"index":"new_twitter"},"max_docs":100,"source":{"index":"twitter"}}`
+	if got != want {
+		t.Fatalf("\ngot  %s\nwant %s", got, want)
+	}
+}
+
 func TestReindexSourceWithScript(t *testing.T) {
 	client := setupTestClient(t)
 	src := NewReindexSource().Index("twitter")
@@ -280,6 +302,80 @@ func TestReindexSourceWithSourceFilter(t *testing.T) {
 	}
 }
 
+func TestReindexSliceAuto(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		N        int
+		Expected string
+	}{
+		{0, "auto"},
+		{-1, "auto"},
+		{1, "1"},
+		{5, "5"},
+	}
+
+	for _, test := range tests {
+		_, params, err := client.Reindex().SliceAuto(test.N).buildURL()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want, have := test.Expected, params.Get("slices"); want != have {
+			t.Errorf("SliceAuto(%d): expected slices = %q; got: %q", test.N, want, have)
+		}
+	}
+}
+
+func TestReindexDoSlicedAsync(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/_reindex":
+			if want, have := "8", r.URL.Query().Get("slices"); want != have {
+				http.Error(w, fmt.Sprintf("expected slices = %q; got: %q", want, have), http.StatusBadRequest)
+				return
+			}
+			if want, have := "false", r.URL.Query().Get("wait_for_completion"); want != have {
+				http.Error(w, fmt.Sprintf("expected wait_for_completion = %q; got: %q", want, have), http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintln(w, `{"task":"oTUltX4IQMOUUVeiohTt8A:12345"}`)
+		case r.Method == "GET" && r.URL.Path == "/_tasks/oTUltX4IQMOUUVeiohTt8A:12345":
+			// Elasticsearch clamped the slices to the number of shards
+			fmt.Fprintln(w, `{"completed":true,"task":{"node":"oTUltX4IQMOUUVeiohTt8A","id":12345,"type":"transport","action":"indices:data/write/reindex","status":{"total":3,"created":3,"slices":[{"slice_id":0,"total":2},{"slice_id":1,"total":1}]}}}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src := NewReindexSource().Index(testIndexName)
+	dst := NewReindexDestination().Index(testIndexName2)
+	taskId, err := client.Reindex().Source(src).Destination(dst).DoSlicedAsync(context.TODO(), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "oTUltX4IQMOUUVeiohTt8A:12345", taskId; want != have {
+		t.Fatalf("expected task id %q; got: %q", want, have)
+	}
+
+	taskStatus, err := client.TasksGetTask().TaskId(taskId).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if taskStatus == nil {
+		t.Fatal("expected task status result != nil")
+	}
+	if !taskStatus.Completed {
+		t.Errorf("expected task to be completed; got: %v", taskStatus.Completed)
+	}
+}
+
 func TestReindex(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) // , SetTraceLog(log.New(os.Stdout, "", 0)))
 
@@ -328,6 +424,40 @@ func TestReindex(t *testing.T) {
 	}
 }
 
+func TestReindexWithMaxDocs(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) // , SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	sourceCount, err := client.Count(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sourceCount < 2 {
+		t.Fatalf("expected at least %d documents; got: %d", 2, sourceCount)
+	}
+
+	// Copy only the first document
+	src := NewReindexSource().Index(testIndexName)
+	dst := NewReindexDestination().Index(testIndexName2)
+	res, err := client.Reindex().Source(src).Destination(dst).MaxDocs(1).Refresh("true").Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected result != nil")
+	}
+	if want, have := int64(1), res.Created; want != have {
+		t.Errorf("expected %d, got %d", want, have)
+	}
+
+	targetCount, err := client.Count(testIndexName2).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(1), targetCount; want != have {
+		t.Fatalf("expected %d documents; got: %d", want, have)
+	}
+}
+
 func TestReindexAsync(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
 	esversion, err := client.ElasticsearchVersion(DefaultURL)
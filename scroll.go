@@ -12,6 +12,7 @@ import (
 	"net/url"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/olivere/elastic/uritemplates"
 )
@@ -21,13 +22,22 @@ const (
 	DefaultScrollKeepAlive = "5m"
 )
 
+var (
+	// defaultScrollPageRetryStatusCodes are the HTTP status codes that
+	// indicate a transient error when fetching a page of a scroll.
+	defaultScrollPageRetryStatusCodes = []int{408, 429, 502, 503, 504}
+)
+
 // ScrollService iterates over pages of search results from Elasticsearch.
 type ScrollService struct {
 	client            *Client
 	retrier           Retrier
+	pageBackoff       Backoff
+	pageRetryCodes    []int
 	indices           []string
 	types             []string
 	keepAlive         string
+	refreshKeepAlive  bool
 	body              interface{}
 	ss                *SearchSource
 	size              *int
@@ -48,9 +58,11 @@ type ScrollService struct {
 // NewScrollService initializes and returns a new ScrollService.
 func NewScrollService(client *Client) *ScrollService {
 	builder := &ScrollService{
-		client:    client,
-		ss:        NewSearchSource(),
-		keepAlive: DefaultScrollKeepAlive,
+		client:           client,
+		ss:               NewSearchSource(),
+		keepAlive:        DefaultScrollKeepAlive,
+		refreshKeepAlive: true,
+		pageRetryCodes:   defaultScrollPageRetryStatusCodes,
 	}
 	return builder
 }
@@ -71,6 +83,24 @@ func (s *ScrollService) Retrier(retrier Retrier) *ScrollService {
 	return s
 }
 
+// PageBackoff enables retrying the fetch of a subsequent page on transient
+// errors, e.g. when a node is temporarily unavailable. Retries preserve the
+// current scroll id and wait according to the given backoff strategy.
+// This is independent of the Retrier, which decides on retrying the
+// underlying HTTP request. Page fetches are not retried by default.
+func (s *ScrollService) PageBackoff(backoff Backoff) *ScrollService {
+	s.pageBackoff = backoff
+	return s
+}
+
+// PageRetryStatusCodes sets the HTTP status codes that are considered
+// transient when fetching a page, and hence are retried if a PageBackoff
+// is set. It defaults to 408, 429, 502, 503, and 504.
+func (s *ScrollService) PageRetryStatusCodes(retryStatusCodes ...int) *ScrollService {
+	s.pageRetryCodes = retryStatusCodes
+	return s
+}
+
 // Index sets the name of one or more indices to iterate over.
 func (s *ScrollService) Index(indices ...string) *ScrollService {
 	if s.indices == nil {
@@ -103,6 +133,28 @@ func (s *ScrollService) KeepAlive(keepAlive string) *ScrollService {
 	return s
 }
 
+// RefreshKeepAlive indicates whether the keep-alive should be re-sent
+// with each subsequent page. It is true by default. If disabled, the
+// scroll parameter is omitted after the first page, and Elasticsearch
+// keeps using the keep-alive passed with the first request.
+func (s *ScrollService) RefreshKeepAlive(refresh bool) *ScrollService {
+	s.refreshKeepAlive = refresh
+	return s
+}
+
+// EffectiveKeepAlive returns the keep-alive that will be sent with the
+// next page. It returns an empty string if the cursor is not refreshed
+// on subsequent pages and the first page has already been fetched.
+func (s *ScrollService) EffectiveKeepAlive() string {
+	s.mu.RLock()
+	scrollId := s.scrollId
+	s.mu.RUnlock()
+	if len(scrollId) > 0 && !s.refreshKeepAlive {
+		return ""
+	}
+	return s.keepAlive
+}
+
 // Size specifies the number of documents Elasticsearch should return
 // from each shard, per page.
 func (s *ScrollService) Size(size int) *ScrollService {
@@ -145,7 +197,12 @@ func (s *ScrollService) PostFilter(postFilter Query) *ScrollService {
 	return s
 }
 
-// Slice allows slicing the scroll request into several batches.
+// Slice allows slicing the scroll request into several batches, e.g. by
+// passing a SliceQuery. Each slice can be consumed independently, e.g. by
+// its own goroutine. All concurrent scrolls must use the same max, and
+// each a distinct slice id less than max.
+// The slice is only sent with the initial search request; subsequent
+// requests only pass the scroll id.
 // This is supported in Elasticsearch 5.0 or later.
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-scroll.html#sliced-scroll
 // for details.
@@ -452,7 +509,7 @@ func (s *ScrollService) next(ctx context.Context) (*SearchResult, error) {
 	}
 
 	// Get HTTP response
-	res, err := s.client.PerformRequest(ctx, PerformRequestOptions{
+	res, err := s.performNext(ctx, PerformRequestOptions{
 		Method:          "POST",
 		Path:            path,
 		Params:          params,
@@ -479,6 +536,43 @@ func (s *ScrollService) next(ctx context.Context) (*SearchResult, error) {
 	return ret, nil
 }
 
+// performNext executes the request for the next page, retrying it on
+// transient errors if a PageBackoff has been specified.
+func (s *ScrollService) performNext(ctx context.Context, opt PerformRequestOptions) (*Response, error) {
+	for retry := 1; ; retry++ {
+		res, err := s.client.PerformRequest(ctx, opt)
+		if err == nil || s.pageBackoff == nil || !s.isTransientPageErr(err) {
+			return res, err
+		}
+		wait, goahead := s.pageBackoff.Next(retry)
+		if !goahead {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isTransientPageErr returns true if the error returned while fetching
+// a page may go away when retrying.
+func (s *ScrollService) isTransientPageErr(err error) bool {
+	if IsContextErr(err) {
+		return false
+	}
+	if IsConnErr(err) {
+		return true
+	}
+	for _, code := range s.pageRetryCodes {
+		if IsStatusCode(err, code) {
+			return true
+		}
+	}
+	return false
+}
+
 // buildNextURL builds the URL for the operation.
 func (s *ScrollService) buildNextURL() (string, url.Values, error) {
 	path := "/_search/scroll"
@@ -496,12 +590,14 @@ func (s *ScrollService) buildNextURL() (string, url.Values, error) {
 func (s *ScrollService) bodyNext() (interface{}, error) {
 	s.mu.RLock()
 	body := struct {
-		Scroll   string `json:"scroll"`
+		Scroll   string `json:"scroll,omitempty"`
 		ScrollId string `json:"scroll_id,omitempty"`
 	}{
-		Scroll:   s.keepAlive,
 		ScrollId: s.scrollId,
 	}
 	s.mu.RUnlock()
+	if s.refreshKeepAlive {
+		body.Scroll = s.keepAlive
+	}
 	return body, nil
 }
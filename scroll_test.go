@@ -7,9 +7,14 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
-	_ "net/http"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestScroll(t *testing.T) {
@@ -387,6 +392,131 @@ func TestScrollWithSlice(t *testing.T) {
 	}
 }
 
+func TestScrollWithSlicesUnion(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t)
+
+	scrollIDs := func(svc *ScrollService) (map[string]bool, error) {
+		ids := make(map[string]bool)
+		for {
+			res, err := svc.Do(context.TODO())
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				return nil, err
+			}
+			for _, hit := range res.Hits.Hits {
+				ids[hit.Id] = true
+			}
+		}
+		if err := svc.Clear(context.TODO()); err != nil {
+			return nil, err
+		}
+		return ids, nil
+	}
+
+	// Scroll over all documents
+	all, err := scrollIDs(client.Scroll(testIndexName).Size(1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 {
+		t.Fatal("expected to retrieve some hits")
+	}
+
+	// Scroll over 2 slices in parallel
+	const max = 2
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		union = make(map[string]bool)
+	)
+	for i := 0; i < max; i++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			svc := client.Scroll(testIndexName).Slice(NewSliceQuery().Id(id).Max(max)).Size(1)
+			ids, err := scrollIDs(svc)
+			if err != nil {
+				t.Errorf("slice %d: %v", id, err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for docID := range ids {
+				if union[docID] {
+					t.Errorf("expected document %q to be returned by a single slice only", docID)
+				}
+				union[docID] = true
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if want, have := len(all), len(union); want != have {
+		t.Fatalf("expected %d documents in the union of slices; got %d", want, have)
+	}
+	for docID := range all {
+		if !union[docID] {
+			t.Errorf("expected document %q to be returned by a slice", docID)
+		}
+	}
+}
+
+func TestScrollWithSliceOnlyInFirstRequest(t *testing.T) {
+	var nextRequests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("expected to decode body; got %v", err)
+		}
+		switch r.URL.Path {
+		case "/" + testIndexName + "/_search":
+			slice, ok := body["slice"].(map[string]interface{})
+			if !ok {
+				t.Errorf("expected slice in initial search body; got %v", body)
+			} else if want, have := float64(2), slice["max"]; want != have {
+				t.Errorf("expected slice max %v; got %v", want, have)
+			}
+			fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1"}]}}`)
+		case "/_search/scroll":
+			if _, found := body["slice"]; found {
+				t.Errorf("expected no slice in scroll body; got %v", body)
+			}
+			if atomic.AddInt32(&nextRequests, 1) == 1 {
+				fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"2"}]}}`)
+			} else {
+				fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[]}}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := client.Scroll(testIndexName).Slice(NewSliceQuery().Id(1).Max(2)).Size(1)
+	docs := 0
+	for {
+		res, err := svc.Do(context.TODO())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs += len(res.Hits.Hits)
+	}
+	if want, have := 2, docs; want != have {
+		t.Fatalf("expected to retrieve %d hits; got %d", want, have)
+	}
+}
+
 func TestScrollWithMaxResponseSize(t *testing.T) {
 	client := setupTestClientAndCreateIndex(t)
 
@@ -524,3 +654,142 @@ func TestScrollWithFilterPath(t *testing.T) {
 		t.Fatal("expected to fail")
 	}
 }
+
+func TestScrollWithRefreshKeepAlive(t *testing.T) {
+	var scrollBodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/" + testIndexName + "/_search":
+			if want, have := "2s", r.URL.Query().Get("scroll"); want != have {
+				t.Errorf("expected scroll=%q in initial request; got %q", want, have)
+			}
+			fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1"}]}}`)
+		case "/_search/scroll":
+			var body map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("expected to decode scroll body; got %v", err)
+			}
+			scrollBodies = append(scrollBodies, body)
+			fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"2"}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const keepAlive = "2s"
+
+	// With refresh enabled, every page extends the cursor
+	svc := client.Scroll(testIndexName).Size(1).KeepAlive(keepAlive).RefreshKeepAlive(true)
+	if want, have := keepAlive, svc.EffectiveKeepAlive(); want != have {
+		t.Fatalf("expected effective keep-alive = %q; got %q", want, have)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Do(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, have := 1, len(scrollBodies); want != have {
+		t.Fatalf("expected %d scroll request; got %d", want, have)
+	}
+	if want, have := keepAlive, scrollBodies[0]["scroll"]; want != have {
+		t.Fatalf("expected scroll = %q in scroll body; got %v", want, have)
+	}
+
+	// With refresh disabled, the keep-alive is only sent initially
+	scrollBodies = nil
+	svc = client.Scroll(testIndexName).Size(1).KeepAlive(keepAlive).RefreshKeepAlive(false)
+	for i := 0; i < 2; i++ {
+		if _, err := svc.Do(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, have := "", svc.EffectiveKeepAlive(); want != have {
+		t.Fatalf("expected effective keep-alive = %q; got %q", want, have)
+	}
+	if want, have := 1, len(scrollBodies); want != have {
+		t.Fatalf("expected %d scroll request; got %d", want, have)
+	}
+	if v, found := scrollBodies[0]["scroll"]; found {
+		t.Fatalf("expected no scroll in scroll body; got %v", v)
+	}
+	if want, have := "c1", scrollBodies[0]["scroll_id"]; want != have {
+		t.Fatalf("expected scroll_id = %q in scroll body; got %v", want, have)
+	}
+}
+
+func TestScrollWithPageBackoff(t *testing.T) {
+	var pageRequests, failures int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/" + testIndexName + "/_search":
+			fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1"}]}}`)
+		case "/_search/scroll":
+			var body struct {
+				ScrollId string `json:"scroll_id"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("expected to decode scroll body; got %v", err)
+			}
+			if want, have := "c1", body.ScrollId; want != have {
+				t.Errorf("expected scroll id %q; got %q", want, have)
+			}
+			n := atomic.AddInt32(&pageRequests, 1)
+			switch n {
+			case 1:
+				// Fail the second page once
+				atomic.AddInt32(&failures, 1)
+				w.WriteHeader(http.StatusServiceUnavailable)
+				fmt.Fprintln(w, `{"error":{"type":"unavailable"},"status":503}`)
+			case 2:
+				fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"2"}]}}`)
+			default:
+				fmt.Fprintln(w, `{"_scroll_id":"c1","hits":{"total":2,"hits":[]}}`)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	svc := client.Scroll(testIndexName).Size(1).PageBackoff(NewConstantBackoff(10 * time.Millisecond))
+	docs := 0
+	for {
+		res, err := svc.Do(context.TODO())
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		docs += len(res.Hits.Hits)
+	}
+	if want, have := 2, docs; want != have {
+		t.Fatalf("expected to retrieve %d hits; got %d", want, have)
+	}
+	if want, have := int32(1), atomic.LoadInt32(&failures); want != have {
+		t.Fatalf("expected %d failed page; got %d", want, have)
+	}
+
+	// Without a page backoff, the scroll fails on the first transient error
+	atomic.StoreInt32(&pageRequests, 0)
+	svc = client.Scroll(testIndexName).Size(1)
+	if _, err := svc.Do(context.TODO()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.Do(context.TODO()); !IsStatusCode(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected error with status %d; got %v", http.StatusServiceUnavailable, err)
+	}
+}
@@ -10,11 +10,39 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/olivere/elastic/uritemplates"
 )
 
+const (
+	// PreferenceLocal prefers to execute the search on locally allocated
+	// shards if possible.
+	PreferenceLocal = "_local"
+	// PreferenceOnlyLocal executes the search only on locally allocated shards.
+	PreferenceOnlyLocal = "_only_local"
+	// PreferencePrimary executes the search on primary shards only.
+	PreferencePrimary = "_primary"
+	// PreferencePrimaryFirst executes the search on primary shards if
+	// possible, and on replica shards otherwise.
+	PreferencePrimaryFirst = "_primary_first"
+	// PreferenceReplica executes the search on replica shards only.
+	PreferenceReplica = "_replica"
+	// PreferenceReplicaFirst executes the search on replica shards if
+	// possible, and on primary shards otherwise.
+	PreferenceReplicaFirst = "_replica_first"
+	// PreferenceShardsPrefix restricts the search to the specified shard
+	// ids, e.g. "_shards:2,3". It can be combined with another preference
+	// by separating them with "|", e.g. "_shards:2,3|_local".
+	PreferenceShardsPrefix = "_shards:"
+	// PreferenceOnlyNodesPrefix restricts the search to the specified nodes.
+	PreferenceOnlyNodesPrefix = "_only_nodes:"
+	// PreferencePreferNodesPrefix prefers to execute the search on the
+	// specified nodes if possible.
+	PreferencePreferNodesPrefix = "_prefer_nodes:"
+)
+
 // Search for documents in Elasticsearch.
 type SearchService struct {
 	client            *Client
@@ -25,13 +53,17 @@ type SearchService struct {
 	searchType        string
 	index             []string
 	typ               []string
-	routing           string
+	routing           []string
 	preference        string
 	requestCache      *bool
+	allowPartial      *bool
 	ignoreUnavailable *bool
 	allowNoIndices    *bool
 	expandWildcards   string
 	maxResponseSize   int64
+
+	minCompatibleShardNode string
+	checkSearchAfter       bool
 }
 
 // NewSearchService creates a new service for searching in Elasticsearch.
@@ -105,6 +137,33 @@ func (s *SearchService) Collapse(collapse *CollapseBuilder) *SearchService {
 	return s
 }
 
+// PointInTime searches the point in time with the given id, as returned
+// by PointInTimeService.Open. The keepAlive extends the lifetime of the
+// point in time, e.g. "1m". As the point in time is bound to the indices
+// it was opened on, the indices and types are omitted from the URL.
+func (s *SearchService) PointInTime(id string, keepAlive string) *SearchService {
+	s.searchSource = s.searchSource.PointInTime(NewPointInTime(id, keepAlive))
+	return s
+}
+
+// RuntimeMappings defines runtime fields for this search request only,
+// see SearchSource.RuntimeMappings.
+func (s *SearchService) RuntimeMappings(runtimeMappings map[string]interface{}) *SearchService {
+	s.searchSource = s.searchSource.RuntimeMappings(runtimeMappings)
+	return s
+}
+
+// Knn adds one or more k-nearest neighbor searches, see SearchSource.Knn.
+func (s *SearchService) Knn(knn ...*KnnQuery) *SearchService {
+	s.searchSource = s.searchSource.Knn(knn...)
+	return s
+}
+
+// KnnSearch is an alias for Knn.
+func (s *SearchService) KnnSearch(knn ...*KnnQuery) *SearchService {
+	return s.Knn(knn...)
+}
+
 // TimeoutInMillis sets the timeout in milliseconds.
 func (s *SearchService) TimeoutInMillis(timeoutInMillis int) *SearchService {
 	s.searchSource = s.searchSource.TimeoutInMillis(timeoutInMillis)
@@ -128,9 +187,13 @@ func (s *SearchService) SearchType(searchType string) *SearchService {
 }
 
 // Routing is a list of specific routing values to control the shards
-// the search will be executed on.
+// the search will be executed on. When searching multiple indices with
+// different routing schemes, pass the routing values of all indices.
+// Pass every routing value as a separate argument, e.g. Routing("a", "b").
+// Routing values must not contain commas, so pre-joined values like
+// Routing("a,b") fail in Validate.
 func (s *SearchService) Routing(routings ...string) *SearchService {
-	s.routing = strings.Join(routings, ",")
+	s.routing = routings
 	return s
 }
 
@@ -138,7 +201,8 @@ func (s *SearchService) Routing(routings ...string) *SearchService {
 // randomize across shards ("random"). Can be set to "_local" to prefer
 // local shards, "_primary" to execute on primary shards only,
 // or a custom value which guarantees that the same order will be used
-// across different requests.
+// across different requests. Use the Preference* constants for the
+// special values. Custom values must not start with "_".
 func (s *SearchService) Preference(preference string) *SearchService {
 	s.preference = preference
 	return s
@@ -151,6 +215,16 @@ func (s *SearchService) RequestCache(requestCache bool) *SearchService {
 	return s
 }
 
+// AllowPartialSearchResults indicates whether Elasticsearch should return
+// partial results if there are shard request timeouts or shard failures.
+// If false, Elasticsearch fails the search on such errors and Do returns
+// the error instead of partial hits. It defaults to the cluster setting
+// search.default_allow_partial_results, which is true by default.
+func (s *SearchService) AllowPartialSearchResults(enabled bool) *SearchService {
+	s.allowPartial = &enabled
+	return s
+}
+
 // Query sets the query to perform, e.g. MatchAllQuery.
 func (s *SearchService) Query(query Query) *SearchService {
 	s.searchSource = s.searchSource.Query(query)
@@ -312,10 +386,27 @@ func (s *SearchService) TrackScores(trackScores bool) *SearchService {
 
 // SearchAfter allows a different form of pagination by using a live cursor,
 // using the results of the previous page to help the retrieval of the next.
+// The number of sort values is checked against the number of sort clauses
+// before the request is sent to Elasticsearch, see SearchAfterCursor.
 //
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-search-after.html
 func (s *SearchService) SearchAfter(sortValues ...interface{}) *SearchService {
-	s.searchSource = s.searchSource.SearchAfter(sortValues...)
+	return s.SearchAfterCursor(NewSearchAfterCursor(sortValues...))
+}
+
+// SearchAfterValues is an alias for SearchAfter.
+func (s *SearchService) SearchAfterValues(sortValues ...interface{}) *SearchService {
+	return s.SearchAfter(sortValues...)
+}
+
+// SearchAfterCursor continues the search after the given cursor, e.g. as
+// returned by NewSearchAfterFromHit for the last hit of the previous page.
+// Validate checks that the number of sort values matches the number of
+// sort clauses. Use this to catch paging bugs early. An empty cursor, e.g.
+// from NewSearchAfterFromHit(nil) for the first page, is not checked.
+func (s *SearchService) SearchAfterCursor(cursor *SearchAfterCursor) *SearchService {
+	s.checkSearchAfter = true
+	s.searchSource = s.searchSource.SearchAfter(cursor.Values()...)
 	return s
 }
 
@@ -348,12 +439,23 @@ func (s *SearchService) MaxResponseSize(maxResponseSize int64) *SearchService {
 	return s
 }
 
+// MinCompatibleShardNode sets the minimum version of a node that a shard
+// may be located on in order to take part in the search, e.g. "7.10.0".
+// This is useful to search mixed-version clusters safely during upgrades.
+func (s *SearchService) MinCompatibleShardNode(version string) *SearchService {
+	s.minCompatibleShardNode = version
+	return s
+}
+
 // buildURL builds the URL for the operation.
 func (s *SearchService) buildURL() (string, url.Values, error) {
 	var err error
 	var path string
 
-	if len(s.index) > 0 && len(s.typ) > 0 {
+	if s.searchSource != nil && s.searchSource.pointInTime != nil {
+		// A point in time is bound to its indices
+		path = "/_search"
+	} else if len(s.index) > 0 && len(s.typ) > 0 {
 		path, err = uritemplates.Expand("/{index}/{type}/_search", map[string]string{
 			"index": strings.Join(s.index, ","),
 			"type":  strings.Join(s.typ, ","),
@@ -381,8 +483,8 @@ func (s *SearchService) buildURL() (string, url.Values, error) {
 	if s.searchType != "" {
 		params.Set("search_type", s.searchType)
 	}
-	if s.routing != "" {
-		params.Set("routing", s.routing)
+	if len(s.routing) > 0 {
+		params.Set("routing", strings.Join(s.routing, ","))
 	}
 	if s.preference != "" {
 		params.Set("preference", s.preference)
@@ -390,6 +492,9 @@ func (s *SearchService) buildURL() (string, url.Values, error) {
 	if s.requestCache != nil {
 		params.Set("request_cache", fmt.Sprintf("%v", *s.requestCache))
 	}
+	if s.allowPartial != nil {
+		params.Set("allow_partial_search_results", fmt.Sprintf("%v", *s.allowPartial))
+	}
 	if s.allowNoIndices != nil {
 		params.Set("allow_no_indices", fmt.Sprintf("%v", *s.allowNoIndices))
 	}
@@ -402,14 +507,84 @@ func (s *SearchService) buildURL() (string, url.Values, error) {
 	if len(s.filterPath) > 0 {
 		params.Set("filter_path", strings.Join(s.filterPath, ","))
 	}
+	if s.minCompatibleShardNode != "" {
+		params.Set("min_compatible_shard_node", s.minCompatibleShardNode)
+	}
 	return path, params, nil
 }
 
 // Validate checks if the operation is valid.
 func (s *SearchService) Validate() error {
+	for _, routing := range s.routing {
+		if strings.Contains(routing, ",") {
+			return fmt.Errorf("routing value %q must not contain commas", routing)
+		}
+	}
+	if s.preference != "" {
+		if err := validatePreference(s.preference); err != nil {
+			return err
+		}
+	}
+	if s.checkSearchAfter && s.source == nil && len(s.searchSource.searchAfterSortValues) > 0 {
+		want, have := len(s.searchSource.sorters), len(s.searchSource.searchAfterSortValues)
+		// Searches on a point in time return an additional tiebreaker
+		// sort value that is passed back with search_after.
+		implicitTiebreaker := s.searchSource.pointInTime != nil && have == want+1
+		if want != have && !implicitTiebreaker {
+			return fmt.Errorf("search_after has %d value(s), but the search is sorted by %d field(s)", have, want)
+		}
+	}
 	return nil
 }
 
+// validatePreference checks the syntax of a search preference.
+func validatePreference(preference string) error {
+	if preference == "" {
+		return fmt.Errorf("preference must not be empty")
+	}
+	if !strings.HasPrefix(preference, "_") {
+		// Custom string value
+		return nil
+	}
+	switch preference {
+	case PreferenceLocal, PreferenceOnlyLocal,
+		PreferencePrimary, PreferencePrimaryFirst,
+		PreferenceReplica, PreferenceReplicaFirst:
+		return nil
+	}
+	switch {
+	case strings.HasPrefix(preference, PreferenceShardsPrefix):
+		shards := strings.TrimPrefix(preference, PreferenceShardsPrefix)
+		var rest string
+		if i := strings.Index(shards, "|"); i >= 0 {
+			shards, rest = shards[:i], shards[i+1:]
+			if err := validatePreference(rest); err != nil {
+				return err
+			}
+		}
+		if shards == "" {
+			return fmt.Errorf("preference %q must specify at least one shard", preference)
+		}
+		for _, shard := range strings.Split(shards, ",") {
+			if n, err := strconv.Atoi(shard); err != nil || n < 0 {
+				return fmt.Errorf("preference %q has invalid shard id %q", preference, shard)
+			}
+		}
+		return nil
+	case strings.HasPrefix(preference, PreferenceOnlyNodesPrefix):
+		if strings.TrimPrefix(preference, PreferenceOnlyNodesPrefix) == "" {
+			return fmt.Errorf("preference %q must specify at least one node", preference)
+		}
+		return nil
+	case strings.HasPrefix(preference, PreferencePreferNodesPrefix):
+		if strings.TrimPrefix(preference, PreferencePreferNodesPrefix) == "" {
+			return fmt.Errorf("preference %q must specify at least one node", preference)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown preference %q; custom values must not start with %q", preference, "_")
+}
+
 // Do executes the search and returns a SearchResult.
 func (s *SearchService) Do(ctx context.Context) (*SearchResult, error) {
 	// Check pre-conditions
@@ -457,6 +632,7 @@ func (s *SearchService) Do(ctx context.Context) (*SearchResult, error) {
 type SearchResult struct {
 	TookInMillis int64          `json:"took,omitempty"`         // search time in milliseconds
 	ScrollId     string         `json:"_scroll_id,omitempty"`   // only used with Scroll and Scan operations
+	PitId        string         `json:"pit_id,omitempty"`       // only used with PointInTime; use it for the next page
 	Hits         *SearchHits    `json:"hits,omitempty"`         // the actual search hits
 	Suggest      SearchSuggest  `json:"suggest,omitempty"`      // results from suggesters
 	Aggregations Aggregations   `json:"aggregations,omitempty"` // results from aggregations
@@ -536,6 +712,64 @@ type SearchHit struct {
 	// MatchedFilters
 }
 
+// HasMatchedQuery returns true if the named query, as set e.g. via
+// TermQuery.QueryName, matched this hit.
+func (hit *SearchHit) HasMatchedQuery(name string) bool {
+	if hit == nil {
+		return false
+	}
+	for _, matched := range hit.MatchedQueries {
+		if matched == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CollapsedHits returns the hits of the named inner hits group, e.g. as
+// specified with CollapseBuilder.InnerHit. The second return value
+// indicates whether the group was found in this hit.
+func (hit *SearchHit) CollapsedHits(name string) (*SearchHits, bool) {
+	if hit == nil {
+		return nil, false
+	}
+	innerHits, found := hit.InnerHits[name]
+	if !found || innerHits == nil || innerHits.Hits == nil {
+		return nil, false
+	}
+	return innerHits.Hits, true
+}
+
+// SearchAfterCursor holds the sort values of a hit, used to retrieve the
+// next page of results with SearchService.SearchAfterCursor.
+type SearchAfterCursor struct {
+	values []interface{}
+}
+
+// NewSearchAfterCursor creates a new cursor from the given sort values.
+func NewSearchAfterCursor(sortValues ...interface{}) *SearchAfterCursor {
+	return &SearchAfterCursor{values: sortValues}
+}
+
+// NewSearchAfterFromHit creates a new cursor from the sort values of the
+// given hit, typically the last hit of the previous page.
+func NewSearchAfterFromHit(hit *SearchHit) *SearchAfterCursor {
+	if hit == nil {
+		return NewSearchAfterCursor()
+	}
+	values := make([]interface{}, len(hit.Sort))
+	copy(values, hit.Sort)
+	return NewSearchAfterCursor(values...)
+}
+
+// Values returns the sort values of the cursor.
+func (c *SearchAfterCursor) Values() []interface{} {
+	if c == nil {
+		return nil
+	}
+	return c.values
+}
+
 // SearchHitInnerHits is used for inner hits.
 type SearchHitInnerHits struct {
 	Hits *SearchHits `json:"hits,omitempty"`
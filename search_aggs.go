@@ -7,6 +7,7 @@ package elastic
 import (
 	"bytes"
 	"encoding/json"
+	"sort"
 )
 
 // Aggregations can be seen as a unit-of-work that build
@@ -218,6 +219,36 @@ func (a Aggregations) TopHits(name string) (*AggregationTopHitsMetric, bool) {
 	return nil, false
 }
 
+// TopMetrics returns top-metrics aggregation results.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.7/search-aggregations-metrics-top-metrics.html
+func (a Aggregations) TopMetrics(name string) (*AggregationTopMetrics, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationTopMetrics)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
+// Rate returns rate aggregation results.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.10/search-aggregations-metrics-rate-aggregation.html
+func (a Aggregations) Rate(name string) (*AggregationValueMetric, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationValueMetric)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // Global returns global results.
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-aggregations-bucket-global-aggregation.html
 func (a Aggregations) Global(name string) (*AggregationSingleBucket, bool) {
@@ -534,6 +565,22 @@ func (a Aggregations) GeoHash(name string) (*AggregationBucketKeyItems, bool) {
 	return nil, false
 }
 
+// GeoHexGrid returns geohex_grid aggregation results. The key of each
+// bucket is the H3 index of its cell.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/8.1/search-aggregations-bucket-geohexgrid-aggregation.html
+func (a Aggregations) GeoHexGrid(name string) (*AggregationBucketKeyItems, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationBucketKeyItems)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(*raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // GeoCentroid returns geo-centroid aggregation results.
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-aggregations-metrics-geocentroid-aggregation.html
 func (a Aggregations) GeoCentroid(name string) (*AggregationGeoCentroidMetric, bool) {
@@ -847,6 +894,26 @@ type AggregationExtendedStatsMetric struct {
 	Variance     *float64               //`json:"variance,omitempty"`
 	StdDeviation *float64               //`json:"std_deviation,omitempty"`
 	Meta         map[string]interface{} // `json:"meta,omitempty"`
+
+	// Population and sampling variants, returned as of Elasticsearch 7.9
+	VariancePopulation     *float64 //`json:"variance_population,omitempty"`
+	VarianceSampling       *float64 //`json:"variance_sampling,omitempty"`
+	StdDeviationPopulation *float64 //`json:"std_deviation_population,omitempty"`
+	StdDeviationSampling   *float64 //`json:"std_deviation_sampling,omitempty"`
+
+	StdDeviationBounds *AggregationExtendedStatsBounds //`json:"std_deviation_bounds,omitempty"`
+}
+
+// AggregationExtendedStatsBounds are the standard deviation bounds of an
+// AggregationExtendedStatsMetric, i.e. the interval of +/- sigma standard
+// deviations from the mean.
+type AggregationExtendedStatsBounds struct {
+	Upper           *float64 `json:"upper,omitempty"`
+	Lower           *float64 `json:"lower,omitempty"`
+	UpperPopulation *float64 `json:"upper_population,omitempty"`
+	LowerPopulation *float64 `json:"lower_population,omitempty"`
+	UpperSampling   *float64 `json:"upper_sampling,omitempty"`
+	LowerSampling   *float64 `json:"lower_sampling,omitempty"`
 }
 
 // UnmarshalJSON decodes JSON data and initializes an AggregationExtendedStatsMetric structure.
@@ -879,6 +946,21 @@ func (a *AggregationExtendedStatsMetric) UnmarshalJSON(data []byte) error {
 	if v, ok := aggs["std_deviation"]; ok && v != nil {
 		json.Unmarshal(*v, &a.StdDeviation)
 	}
+	if v, ok := aggs["variance_population"]; ok && v != nil {
+		json.Unmarshal(*v, &a.VariancePopulation)
+	}
+	if v, ok := aggs["variance_sampling"]; ok && v != nil {
+		json.Unmarshal(*v, &a.VarianceSampling)
+	}
+	if v, ok := aggs["std_deviation_population"]; ok && v != nil {
+		json.Unmarshal(*v, &a.StdDeviationPopulation)
+	}
+	if v, ok := aggs["std_deviation_sampling"]; ok && v != nil {
+		json.Unmarshal(*v, &a.StdDeviationSampling)
+	}
+	if v, ok := aggs["std_deviation_bounds"]; ok && v != nil {
+		json.Unmarshal(*v, &a.StdDeviationBounds)
+	}
 	if v, ok := aggs["meta"]; ok && v != nil {
 		json.Unmarshal(*v, &a.Meta)
 	}
@@ -979,6 +1061,38 @@ func (a *AggregationTopHitsMetric) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// -- Top-metrics metric --
+
+// AggregationTopMetrics is the result of a TopMetricsAggregation.
+type AggregationTopMetrics struct {
+	Aggregations
+
+	Top  []TopMetricsEntry      //`json:"top"`
+	Meta map[string]interface{} // `json:"meta,omitempty"`
+}
+
+// TopMetricsEntry is a single top document of an AggregationTopMetrics.
+type TopMetricsEntry struct {
+	Sort    []interface{}          `json:"sort"`
+	Metrics map[string]interface{} `json:"metrics"`
+}
+
+// UnmarshalJSON decodes JSON data and initializes an AggregationTopMetrics structure.
+func (a *AggregationTopMetrics) UnmarshalJSON(data []byte) error {
+	var aggs map[string]*json.RawMessage
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return err
+	}
+	if v, ok := aggs["top"]; ok && v != nil {
+		json.Unmarshal(*v, &a.Top)
+	}
+	if v, ok := aggs["meta"]; ok && v != nil {
+		json.Unmarshal(*v, &a.Meta)
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
 // -- Geo-bounds metric --
 
 // AggregationGeoBoundsMetric is a metric as returned by a GeoBounds aggregation.
@@ -1225,6 +1339,10 @@ type AggregationBucketKeyItem struct {
 	KeyAsString *string     //`json:"key_as_string"`
 	KeyNumber   json.Number
 	DocCount    int64 //`json:"doc_count"`
+
+	// DocCountErrorUpperBound is only returned by terms aggregations
+	// with ShowTermDocCountError enabled.
+	DocCountErrorUpperBound int64 //`json:"doc_count_error_upper_bound"`
 }
 
 // UnmarshalJSON decodes JSON data and initializes an AggregationBucketKeyItem structure.
@@ -1245,6 +1363,9 @@ func (a *AggregationBucketKeyItem) UnmarshalJSON(data []byte) error {
 	if v, ok := aggs["doc_count"]; ok && v != nil {
 		json.Unmarshal(*v, &a.DocCount)
 	}
+	if v, ok := aggs["doc_count_error_upper_bound"]; ok && v != nil {
+		json.Unmarshal(*v, &a.DocCountErrorUpperBound)
+	}
 	a.Aggregations = aggs
 	return nil
 }
@@ -1341,6 +1462,20 @@ func (a *AggregationBucketFilters) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// OtherBucket returns the bucket of the documents that matched none of
+// the named filters, i.e. the bucket with the given key, or "_other_" if
+// key is empty. It only works with named filters and returns false for
+// unnamed filters, whose response does not tell the other bucket apart
+// from the others. In that case, the other bucket (if requested) is the
+// last element of Buckets.
+func (a *AggregationBucketFilters) OtherBucket(key string) (*AggregationBucketKeyItem, bool) {
+	if key == "" {
+		key = "_other_"
+	}
+	bucket, found := a.NamedBuckets[key]
+	return bucket, found && bucket != nil
+}
+
 // -- Bucket AdjacencyMatrix --
 
 // AggregationBucketAdjacencyMatrix is a multi-bucket aggregation that is returned
@@ -1380,13 +1515,27 @@ type AggregationBucketHistogramItems struct {
 }
 
 // UnmarshalJSON decodes JSON data and initializes an AggregationBucketHistogramItems structure.
+// Buckets of a keyed response are decoded as well and sorted by key.
 func (a *AggregationBucketHistogramItems) UnmarshalJSON(data []byte) error {
 	var aggs map[string]*json.RawMessage
 	if err := json.Unmarshal(data, &aggs); err != nil {
 		return err
 	}
 	if v, ok := aggs["buckets"]; ok && v != nil {
-		json.Unmarshal(*v, &a.Buckets)
+		if b := bytes.TrimSpace(*v); len(b) > 0 && b[0] == '{' {
+			var keyed map[string]*AggregationBucketHistogramItem
+			json.Unmarshal(*v, &keyed)
+			for _, bucket := range keyed {
+				if bucket != nil {
+					a.Buckets = append(a.Buckets, bucket)
+				}
+			}
+			sort.Slice(a.Buckets, func(i, j int) bool {
+				return a.Buckets[i].Key < a.Buckets[j].Key
+			})
+		} else {
+			json.Unmarshal(*v, &a.Buckets)
+		}
 	}
 	if v, ok := aggs["meta"]; ok && v != nil {
 		json.Unmarshal(*v, &a.Meta)
@@ -1675,6 +1824,17 @@ func (a *AggregationBucketCompositeItems) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// NextAfterKey returns the after_key of the composite aggregation, which can
+// be passed to CompositeAggregation.AggregateAfter to retrieve the next page
+// of buckets. It returns nil and false on the last page, i.e. when
+// Elasticsearch returns no after_key.
+func (a *AggregationBucketCompositeItems) NextAfterKey() (map[string]interface{}, bool) {
+	if a == nil || len(a.AfterKey) == 0 {
+		return nil, false
+	}
+	return a.AfterKey, true
+}
+
 // AggregationBucketCompositeItem is a single bucket of an AggregationBucketCompositeItems structure.
 type AggregationBucketCompositeItem struct {
 	Aggregations
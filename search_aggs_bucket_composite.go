@@ -4,6 +4,8 @@
 
 package elastic
 
+import "fmt"
+
 // CompositeAggregation is a multi-bucket values source based aggregation
 // that can be used to calculate unique composite values from source documents.
 //
@@ -39,6 +41,19 @@ func (a *CompositeAggregation) AggregateAfter(after map[string]interface{}) *Com
 	return a
 }
 
+// AfterKeyFrom copies the after_key of a composite aggregation response into
+// this aggregation, so that the request returns the next page of buckets.
+// It returns false if the response has no after_key, i.e. on the last page,
+// and leaves the after key of this aggregation unchanged in that case.
+func (a *CompositeAggregation) AfterKeyFrom(agg *AggregationBucketCompositeItems) bool {
+	after, found := agg.NextAfterKey()
+	if !found {
+		return false
+	}
+	a.AggregateAfter(after)
+	return true
+}
+
 // Sources specifies the list of CompositeAggregationValuesSource instances to
 // use in the aggregation.
 func (a *CompositeAggregation) Sources(sources ...CompositeAggregationValuesSource) *CompositeAggregation {
@@ -148,6 +163,7 @@ type CompositeAggregationTermsValuesSource struct {
 	valueType     string
 	missing       interface{}
 	missingBucket *bool
+	missingOrder  string
 	order         string
 }
 
@@ -213,6 +229,13 @@ func (a *CompositeAggregationTermsValuesSource) MissingBucket(missingBucket bool
 	return a
 }
 
+// MissingOrder specifies where the explicit null bucket (see MissingBucket)
+// is placed. It can be "first", "last", or "default".
+func (a *CompositeAggregationTermsValuesSource) MissingOrder(missingOrder string) *CompositeAggregationTermsValuesSource {
+	a.missingOrder = missingOrder
+	return a
+}
+
 // Source returns the serializable JSON for this values source.
 func (a *CompositeAggregationTermsValuesSource) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -245,6 +268,14 @@ func (a *CompositeAggregationTermsValuesSource) Source() (interface{}, error) {
 		values["missing_bucket"] = *a.missingBucket
 	}
 
+	// missing_order
+	if a.missingOrder != "" {
+		if err := validateCompositeMissingOrder(a.missingOrder); err != nil {
+			return nil, err
+		}
+		values["missing_order"] = a.missingOrder
+	}
+
 	// value_type
 	if a.valueType != "" {
 		values["value_type"] = a.valueType
@@ -273,6 +304,7 @@ type CompositeAggregationHistogramValuesSource struct {
 	valueType     string
 	missing       interface{}
 	missingBucket *bool
+	missingOrder  string
 	order         string
 	interval      float64
 }
@@ -321,6 +353,13 @@ func (a *CompositeAggregationHistogramValuesSource) MissingBucket(missingBucket
 	return a
 }
 
+// MissingOrder specifies where the explicit null bucket (see MissingBucket)
+// is placed. It can be "first", "last", or "default".
+func (a *CompositeAggregationHistogramValuesSource) MissingOrder(missingOrder string) *CompositeAggregationHistogramValuesSource {
+	a.missingOrder = missingOrder
+	return a
+}
+
 // Order specifies the order in the values produced by this source.
 // It can be either "asc" or "desc".
 func (a *CompositeAggregationHistogramValuesSource) Order(order string) *CompositeAggregationHistogramValuesSource {
@@ -378,6 +417,14 @@ func (a *CompositeAggregationHistogramValuesSource) Source() (interface{}, error
 		values["missing_bucket"] = *a.missingBucket
 	}
 
+	// missing_order
+	if a.missingOrder != "" {
+		if err := validateCompositeMissingOrder(a.missingOrder); err != nil {
+			return nil, err
+		}
+		values["missing_order"] = a.missingOrder
+	}
+
 	// value_type
 	if a.valueType != "" {
 		values["value_type"] = a.valueType
@@ -409,6 +456,7 @@ type CompositeAggregationDateHistogramValuesSource struct {
 	valueType     string
 	missing       interface{}
 	missingBucket *bool
+	missingOrder  string
 	order         string
 	interval      interface{}
 	format        string
@@ -459,6 +507,13 @@ func (a *CompositeAggregationDateHistogramValuesSource) MissingBucket(missingBuc
 	return a
 }
 
+// MissingOrder specifies where the explicit null bucket (see MissingBucket)
+// is placed. It can be "first", "last", or "default".
+func (a *CompositeAggregationDateHistogramValuesSource) MissingOrder(missingOrder string) *CompositeAggregationDateHistogramValuesSource {
+	a.missingOrder = missingOrder
+	return a
+}
+
 // Order specifies the order in the values produced by this source.
 // It can be either "asc" or "desc".
 func (a *CompositeAggregationDateHistogramValuesSource) Order(order string) *CompositeAggregationDateHistogramValuesSource {
@@ -485,6 +540,8 @@ func (a *CompositeAggregationDateHistogramValuesSource) Interval(interval interf
 }
 
 // Format to use for the date histogram, e.g. "strict_date_optional_time"
+// or "yyyy-MM-dd". The keys of the buckets, including the after_key, are
+// then returned as formatted strings instead of epoch milliseconds.
 func (a *CompositeAggregationDateHistogramValuesSource) Format(format string) *CompositeAggregationDateHistogramValuesSource {
 	a.format = format
 	return a
@@ -528,6 +585,14 @@ func (a *CompositeAggregationDateHistogramValuesSource) Source() (interface{}, e
 		values["missing_bucket"] = *a.missingBucket
 	}
 
+	// missing_order
+	if a.missingOrder != "" {
+		if err := validateCompositeMissingOrder(a.missingOrder); err != nil {
+			return nil, err
+		}
+		values["missing_order"] = a.missingOrder
+	}
+
 	// value_type
 	if a.valueType != "" {
 		values["value_type"] = a.valueType
@@ -552,3 +617,12 @@ func (a *CompositeAggregationDateHistogramValuesSource) Source() (interface{}, e
 
 	return source, nil
 }
+
+// validateCompositeMissingOrder checks the missing_order of a values source.
+func validateCompositeMissingOrder(missingOrder string) error {
+	switch missingOrder {
+	case "first", "last", "default":
+		return nil
+	}
+	return fmt.Errorf("elastic: invalid missing_order %q in composite values source; must be one of first, last, or default", missingOrder)
+}
@@ -37,6 +37,84 @@ func TestCompositeAggregation(t *testing.T) {
 	}
 }
 
+func TestCompositeAggregationAfterKeyFromResponse(t *testing.T) {
+	s := `{
+	"my_composite" : {
+		"after_key" : {
+			"my_terms" : "sandrae",
+			"my_histogram" : 10.0
+		},
+		"buckets" : [
+			{
+				"key" : {
+					"my_terms" : "sandrae",
+					"my_histogram" : 10.0
+				},
+				"doc_count" : 2
+			}
+		]
+	}
+	}`
+
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	res, found := aggs.Composite("my_composite")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+
+	// Feed the after key of the response into the next request
+	agg := NewCompositeAggregation().
+		Sources(
+			NewCompositeAggregationTermsValuesSource("my_terms").Field("user"),
+			NewCompositeAggregationHistogramValuesSource("my_histogram", 5).Field("retweets"),
+		).
+		Size(1)
+	if !agg.AfterKeyFrom(res) {
+		t.Fatal("expected an after key")
+	}
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"composite":{"after":{"my_histogram":10,"my_terms":"sandrae"},"size":1,"sources":[{"my_terms":{"terms":{"field":"user"}}},{"my_histogram":{"histogram":{"field":"retweets","interval":5}}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// A response without after_key ends the pagination and keeps the after key
+	if err := json.Unmarshal([]byte(`{"my_composite":{"buckets":[]}}`), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	res, found = aggs.Composite("my_composite")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg.AfterKeyFrom(res) {
+		t.Fatal("expected no after key on the last page")
+	}
+	src, err = agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got = string(data)
+	expected = `{"composite":{"after":{"my_histogram":10,"my_terms":"sandrae"},"size":1,"sources":[{"my_terms":{"terms":{"field":"user"}}},{"my_histogram":{"histogram":{"field":"retweets","interval":5}}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestCompositeAggregationTermsValuesSource(t *testing.T) {
 	in := NewCompositeAggregationTermsValuesSource("products").
 		Script(NewScript("doc['product'].value").Lang("painless"))
@@ -91,3 +169,71 @@ func TestCompositeAggregationDateHistogramValuesSource(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestCompositeAggregationValuesSourceWithMissingOrder(t *testing.T) {
+	tests := []struct {
+		Source   CompositeAggregationValuesSource
+		Expected string
+	}{
+		{
+			NewCompositeAggregationTermsValuesSource("products").Field("product").MissingBucket(true).MissingOrder("last"),
+			`{"products":{"terms":{"field":"product","missing_bucket":true,"missing_order":"last"}}}`,
+		},
+		{
+			NewCompositeAggregationHistogramValuesSource("histo", 5).Field("price").MissingBucket(true).MissingOrder("first"),
+			`{"histo":{"histogram":{"field":"price","interval":5,"missing_bucket":true,"missing_order":"first"}}}`,
+		},
+		{
+			NewCompositeAggregationDateHistogramValuesSource("date", "1d").Field("timestamp").MissingBucket(true).MissingOrder("default"),
+			`{"date":{"date_histogram":{"field":"timestamp","interval":"1d","missing_bucket":true,"missing_order":"default"}}}`,
+		},
+	}
+
+	for i, test := range tests {
+		src, err := test.Source.Source()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("case #%d: marshaling to JSON failed: %v", i+1, err)
+		}
+		if want, have := test.Expected, string(data); want != have {
+			t.Errorf("case #%d: expected\n%s\n,got:\n%s", i+1, want, have)
+		}
+	}
+}
+
+func TestCompositeAggregationValuesSourceWithInvalidMissingOrder(t *testing.T) {
+	sources := []CompositeAggregationValuesSource{
+		NewCompositeAggregationTermsValuesSource("products").Field("product").MissingOrder("LAST"),
+		NewCompositeAggregationHistogramValuesSource("histo", 5).Field("price").MissingOrder("middle"),
+		NewCompositeAggregationDateHistogramValuesSource("date", "1d").Field("timestamp").MissingOrder("none"),
+	}
+	for i, source := range sources {
+		if _, err := source.Source(); err == nil {
+			t.Errorf("case #%d: expected error for invalid missing_order; got: nil", i+1)
+		}
+	}
+}
+
+func TestCompositeAggregationDateHistogramValuesSourceWithFormatAndAfter(t *testing.T) {
+	agg := NewCompositeAggregation().
+		Sources(NewCompositeAggregationDateHistogramValuesSource("date", "1d").
+			Field("timestamp").
+			Format("yyyy-MM-dd")).
+		AggregateAfter(map[string]interface{}{"date": "2018-05-01"})
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"composite":{"after":{"date":"2018-05-01"},"sources":[{"date":{"date_histogram":{"field":"timestamp","format":"yyyy-MM-dd","interval":"1d"}}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -4,6 +4,8 @@
 
 package elastic
 
+import "errors"
+
 // DateHistogramAggregation is a multi-bucket aggregation similar to the
 // histogram except it can only be applied on date values.
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-aggregations-bucket-datehistogram-aggregation.html
@@ -15,6 +17,8 @@ type DateHistogramAggregation struct {
 	meta            map[string]interface{}
 
 	interval          string
+	fixedInterval     string
+	calendarInterval  string
 	order             string
 	orderAsc          bool
 	minDocCount       *int64
@@ -65,11 +69,30 @@ func (a *DateHistogramAggregation) Meta(metaData map[string]interface{}) *DateHi
 // Allowed values are: "year", "quarter", "month", "week", "day",
 // "hour", "minute". It also supports time settings like "1.5h"
 // (up to "w" for weeks).
+//
+// Deprecated: Use FixedInterval or CalendarInterval as of Elasticsearch 7.2.
 func (a *DateHistogramAggregation) Interval(interval string) *DateHistogramAggregation {
 	a.interval = interval
 	return a
 }
 
+// FixedInterval sets a fixed interval in SI units, e.g. "90m" or "2d".
+// Fixed intervals are always the same length and cannot be combined with
+// CalendarInterval. It is supported as of Elasticsearch 7.2.
+func (a *DateHistogramAggregation) FixedInterval(fixedInterval string) *DateHistogramAggregation {
+	a.fixedInterval = fixedInterval
+	return a
+}
+
+// CalendarInterval sets a calendar-aware interval, e.g. "1M" or "month".
+// Calendar intervals take daylight savings and varying month lengths into
+// account and cannot be combined with FixedInterval. It is supported as of
+// Elasticsearch 7.2.
+func (a *DateHistogramAggregation) CalendarInterval(calendarInterval string) *DateHistogramAggregation {
+	a.calendarInterval = calendarInterval
+	return a
+}
+
 // Order specifies the sort order. Valid values for order are:
 // "_key", "_count", a sub-aggregation name, or a sub-aggregation name
 // with a metric.
@@ -239,7 +262,23 @@ func (a *DateHistogramAggregation) Source() (interface{}, error) {
 		opts["missing"] = a.missing
 	}
 
-	opts["interval"] = a.interval
+	n := 0
+	for _, interval := range []string{a.interval, a.fixedInterval, a.calendarInterval} {
+		if interval != "" {
+			n++
+		}
+	}
+	if n > 1 {
+		return nil, errors.New("elastic: date_histogram accepts only one of interval, fixed_interval, or calendar_interval")
+	}
+	switch {
+	case a.fixedInterval != "":
+		opts["fixed_interval"] = a.fixedInterval
+	case a.calendarInterval != "":
+		opts["calendar_interval"] = a.calendarInterval
+	default:
+		opts["interval"] = a.interval
+	}
 	if a.minDocCount != nil {
 		opts["min_doc_count"] = *a.minDocCount
 	}
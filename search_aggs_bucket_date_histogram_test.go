@@ -64,3 +64,67 @@ func TestDateHistogramAggregationWithMissing(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestDateHistogramAggregationWithFixedInterval(t *testing.T) {
+	agg := NewDateHistogramAggregation().Field("date").FixedInterval("90m")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"date_histogram":{"field":"date","fixed_interval":"90m"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDateHistogramAggregationWithCalendarInterval(t *testing.T) {
+	agg := NewDateHistogramAggregation().Field("date").CalendarInterval("1M")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"date_histogram":{"calendar_interval":"1M","field":"date"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDateHistogramAggregationWithConflictingIntervals(t *testing.T) {
+	tests := []*DateHistogramAggregation{
+		NewDateHistogramAggregation().Field("date").FixedInterval("1d").CalendarInterval("1d"),
+		NewDateHistogramAggregation().Field("date").Interval("day").FixedInterval("1d"),
+		NewDateHistogramAggregation().Field("date").Interval("day").CalendarInterval("1d"),
+	}
+	for i, agg := range tests {
+		if _, err := agg.Source(); err == nil {
+			t.Errorf("#%d: expected error when setting more than one interval", i)
+		}
+	}
+}
+
+func TestDateHistogramAggregationWithOffsetAndKeyed(t *testing.T) {
+	agg := NewDateHistogramAggregation().Field("date").CalendarInterval("day").Offset("+8h").Keyed(true)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"date_histogram":{"calendar_interval":"day","field":"date","keyed":true,"offset":"+8h"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
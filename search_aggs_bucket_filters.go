@@ -19,6 +19,8 @@ import "errors"
 type FiltersAggregation struct {
 	unnamedFilters  []Query
 	namedFilters    map[string]Query
+	otherBucket     *bool
+	otherBucketKey  string
 	subAggregations map[string]Aggregation
 	meta            map[string]interface{}
 }
@@ -55,6 +57,20 @@ func (a *FiltersAggregation) FilterWithName(name string, filter Query) *FiltersA
 	return a
 }
 
+// OtherBucket indicates whether to add a bucket to the response which
+// contains all documents that do not match any of the filters.
+func (a *FiltersAggregation) OtherBucket(otherBucket bool) *FiltersAggregation {
+	a.otherBucket = &otherBucket
+	return a
+}
+
+// OtherBucketKey sets the key of the other bucket (default: "_other_").
+// Setting it implicitly enables OtherBucket in Elasticsearch.
+func (a *FiltersAggregation) OtherBucketKey(otherBucketKey string) *FiltersAggregation {
+	a.otherBucketKey = otherBucketKey
+	return a
+}
+
 // SubAggregation adds a sub-aggregation to this aggregation.
 func (a *FiltersAggregation) SubAggregation(name string, subAggregation Aggregation) *FiltersAggregation {
 	a.subAggregations[name] = subAggregation
@@ -115,6 +131,12 @@ func (a *FiltersAggregation) Source() (interface{}, error) {
 		}
 		filters["filters"] = dict
 	}
+	if a.otherBucket != nil {
+		filters["other_bucket"] = *a.otherBucket
+	}
+	if a.otherBucketKey != "" {
+		filters["other_bucket_key"] = a.otherBucketKey
+	}
 
 	// AggregationBuilder (SubAggregations)
 	if len(a.subAggregations) > 0 {
@@ -0,0 +1,139 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// GeoHexGridAggregation is a multi-bucket aggregation that groups geo_point
+// values into buckets that represent cells in an H3 grid. Each bucket key
+// is the H3 index of the cell. It is supported as of Elasticsearch 8.1.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/8.1/search-aggregations-bucket-geohexgrid-aggregation.html
+// for details.
+type GeoHexGridAggregation struct {
+	field           string
+	precision       *int
+	topLeft         *GeoPoint
+	bottomRight     *GeoPoint
+	size            *int
+	shardSize       *int
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+}
+
+// NewGeoHexGridAggregation creates a new GeoHexGridAggregation.
+func NewGeoHexGridAggregation() *GeoHexGridAggregation {
+	return &GeoHexGridAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field is the name of a geo_point field to aggregate on.
+func (a *GeoHexGridAggregation) Field(field string) *GeoHexGridAggregation {
+	a.field = field
+	return a
+}
+
+// Precision is the H3 resolution of the cells, between 0 and 15
+// (default: 6).
+func (a *GeoHexGridAggregation) Precision(precision int) *GeoHexGridAggregation {
+	a.precision = &precision
+	return a
+}
+
+// Bounds restricts the aggregation to the cells that intersect the
+// bounding box given by its top left and bottom right corners.
+func (a *GeoHexGridAggregation) Bounds(topLeft, bottomRight *GeoPoint) *GeoHexGridAggregation {
+	a.topLeft = topLeft
+	a.bottomRight = bottomRight
+	return a
+}
+
+// Size is the maximum number of buckets to return (default: 10000).
+func (a *GeoHexGridAggregation) Size(size int) *GeoHexGridAggregation {
+	a.size = &size
+	return a
+}
+
+// ShardSize is the maximum number of buckets to return from each shard.
+func (a *GeoHexGridAggregation) ShardSize(shardSize int) *GeoHexGridAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *GeoHexGridAggregation) SubAggregation(name string, subAggregation Aggregation) *GeoHexGridAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *GeoHexGridAggregation) Meta(metaData map[string]interface{}) *GeoHexGridAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the a JSON-serializable interface.
+func (a *GeoHexGridAggregation) Source() (interface{}, error) {
+	// Example:
+	// {
+	//     "aggs": {
+	//         "large-grid": {
+	//             "geohex_grid": {
+	//                 "field": "location",
+	//                 "precision": 4
+	//             }
+	//         }
+	//     }
+	// }
+
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["geohex_grid"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.precision != nil {
+		if *a.precision < 0 || *a.precision > 15 {
+			return nil, fmt.Errorf("elastic: invalid precision %d in GeoHexGridAggregation; must be between 0 and 15", *a.precision)
+		}
+		opts["precision"] = *a.precision
+	}
+	if a.topLeft != nil || a.bottomRight != nil {
+		if a.topLeft == nil || a.bottomRight == nil {
+			return nil, fmt.Errorf("elastic: GeoHexGridAggregation requires both top left and bottom right of bounds")
+		}
+		opts["bounds"] = map[string]interface{}{
+			"top_left":     a.topLeft.Source(),
+			"bottom_right": a.bottomRight.Source(),
+		}
+	}
+	if a.size != nil {
+		opts["size"] = *a.size
+	}
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+
+	// AggregationBuilder (SubAggregations)
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,59 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoHexGridAggregation(t *testing.T) {
+	agg := NewGeoHexGridAggregation().Field("location").Precision(4)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geohex_grid":{"field":"location","precision":4}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoHexGridAggregationWithOptions(t *testing.T) {
+	agg := NewGeoHexGridAggregation().
+		Field("location").
+		Precision(12).
+		Bounds(GeoPointFromLatLon(52.5, 4.8), GeoPointFromLatLon(52.3, 5.0)).
+		Size(100).
+		ShardSize(200).
+		SubAggregation("avg_price", NewAvgAggregation().Field("price")).
+		Meta(map[string]interface{}{"name": "Oliver"})
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"avg_price":{"avg":{"field":"price"}}},"geohex_grid":{"bounds":{"bottom_right":{"lat":52.3,"lon":5},"top_left":{"lat":52.5,"lon":4.8}},"field":"location","precision":12,"shard_size":200,"size":100},"meta":{"name":"Oliver"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoHexGridAggregationWithInvalidPrecision(t *testing.T) {
+	for _, precision := range []int{-1, 16} {
+		if _, err := NewGeoHexGridAggregation().Field("location").Precision(precision).Source(); err == nil {
+			t.Errorf("expected error for precision %d; got: nil", precision)
+		}
+	}
+}
@@ -22,6 +22,8 @@ type HistogramAggregation struct {
 	minDocCount *int64
 	minBounds   *float64
 	maxBounds   *float64
+	hardMin     *float64
+	hardMax     *float64
 	offset      *float64
 }
 
@@ -150,6 +152,9 @@ func (a *HistogramAggregation) MinDocCount(minDocCount int64) *HistogramAggregat
 	return a
 }
 
+// ExtendedBounds forces the histogram to start building buckets on min
+// and keep on building buckets up to max, even if there are no documents
+// in them. Use it in combination with MinDocCount(0).
 func (a *HistogramAggregation) ExtendedBounds(min, max float64) *HistogramAggregation {
 	a.minBounds = &min
 	a.maxBounds = &max
@@ -176,6 +181,14 @@ func (a *HistogramAggregation) MaxBounds(max float64) *HistogramAggregation {
 	return a
 }
 
+// HardBounds limits the range of buckets in the histogram to [min,max].
+// Buckets outside of it are not returned, even if documents fall into them.
+func (a *HistogramAggregation) HardBounds(min, max float64) *HistogramAggregation {
+	a.hardMin = &min
+	a.hardMax = &max
+	return a
+}
+
 // Offset into the histogram
 func (a *HistogramAggregation) Offset(offset float64) *HistogramAggregation {
 	a.offset = &offset
@@ -242,6 +255,16 @@ func (a *HistogramAggregation) Source() (interface{}, error) {
 		}
 		opts["extended_bounds"] = bounds
 	}
+	if a.hardMin != nil || a.hardMax != nil {
+		bounds := make(map[string]interface{})
+		if a.hardMin != nil {
+			bounds["min"] = a.hardMin
+		}
+		if a.hardMax != nil {
+			bounds["max"] = a.hardMax
+		}
+		opts["hard_bounds"] = bounds
+	}
 
 	// AggregationBuilder (SubAggregations)
 	if len(a.subAggregations) > 0 {
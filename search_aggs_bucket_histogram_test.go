@@ -5,6 +5,7 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 )
@@ -59,3 +60,69 @@ func TestHistogramAggregationWithMissing(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestHistogramAggregationWithExtendedBounds(t *testing.T) {
+	agg := NewHistogramAggregation().Field("price").Interval(50).MinDocCount(0).ExtendedBounds(0, 500)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"histogram":{"extended_bounds":{"max":500,"min":0},"field":"price","interval":50,"min_doc_count":0}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestHistogramAggregationWithHardBounds(t *testing.T) {
+	agg := NewHistogramAggregation().Field("price").Interval(50).HardBounds(100, 200)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"histogram":{"field":"price","hard_bounds":{"max":200,"min":100},"interval":50}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestHistogramAggregationWithExtendedBoundsIntegration(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	searchResult, err := client.Search().
+		Index(testIndexName).
+		Query(NewMatchAllQuery()).
+		Size(0).
+		Aggregation("retweets", NewHistogramAggregation().Field("retweets").Interval(100).MinDocCount(0).ExtendedBounds(0, 300)).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agg, found := searchResult.Aggregations.Histogram("retweets")
+	if !found || agg == nil {
+		t.Fatalf("expected histogram aggregation; got: %v", agg)
+	}
+	// Documents only fall into the buckets 0 and 100, but the extended
+	// bounds add empty buckets up to 300
+	if want, have := 4, len(agg.Buckets); want != have {
+		t.Fatalf("expected %d buckets; got: %d", want, have)
+	}
+	for i, key := range []float64{0, 100, 200, 300} {
+		if agg.Buckets[i].Key != key {
+			t.Errorf("expected bucket %d to have key %v; got: %v", i, key, agg.Buckets[i].Key)
+		}
+	}
+	if agg.Buckets[2].DocCount != 0 || agg.Buckets[3].DocCount != 0 {
+		t.Errorf("expected empty buckets within extended bounds; got: %d and %d", agg.Buckets[2].DocCount, agg.Buckets[3].DocCount)
+	}
+}
@@ -5,7 +5,9 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"testing"
 )
 
@@ -137,6 +139,82 @@ func TestRangeAggregationWithKeys(t *testing.T) {
 	}
 }
 
+func TestRangeAggregationWithScript(t *testing.T) {
+	agg := NewRangeAggregation().
+		Script(NewScript("doc['price'].value * params.rate").Param("rate", 1.1)).
+		Keyed(true).
+		AddUnboundedFromWithKey("cheap", 50).
+		AddRangeWithKey("average", 50, 100).
+		AddUnboundedToWithKey("expensive", 100)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"keyed":true,"ranges":[{"key":"cheap","to":50},{"from":50,"key":"average","to":100},{"from":100,"key":"expensive"}],"script":{"params":{"rate":1.1},"source":"doc['price'].value * params.rate"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRangeAggregationWithScriptIntegration(t *testing.T) {
+	client := setupTestClientAndCreateIndex(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	tweets := []tweet{
+		{User: "olivere", Message: "Welcome to Golang and Elasticsearch.", Retweets: 108},
+		{User: "olivere", Message: "Another unrelated topic.", Retweets: 0},
+		{User: "sandrae", Message: "Cycling is fun.", Retweets: 12},
+	}
+	for i, tweet := range tweets {
+		_, err := client.Index().Index(testIndexName).Type("doc").Id(fmt.Sprint(i + 1)).BodyJson(&tweet).Do(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	_, err := client.Refresh().Index(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agg := NewRangeAggregation().
+		Script(NewScript("doc['retweets'].value * params.factor").Param("factor", 10)).
+		Keyed(true).
+		AddUnboundedFromWithKey("low", 100).
+		AddRangeWithKey("mid", 100, 500).
+		AddUnboundedToWithKey("high", 500)
+	searchResult, err := client.Search().
+		Index(testIndexName).
+		Query(NewMatchAllQuery()).
+		Size(0).
+		Aggregation("reach", agg).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, found := searchResult.Aggregations.KeyedRange("reach")
+	if !found || res == nil {
+		t.Fatalf("expected keyed range aggregation; got: %v", res)
+	}
+	if want, have := 3, len(res.Buckets); want != have {
+		t.Fatalf("expected %d buckets; got: %d", want, have)
+	}
+	for _, key := range []string{"low", "mid", "high"} {
+		bucket := res.Buckets[key]
+		if bucket == nil {
+			t.Errorf("expected bucket %q", key)
+			continue
+		}
+		if want, have := int64(1), bucket.DocCount; want != have {
+			t.Errorf("expected %d documents in bucket %q; got: %d", want, key, have)
+		}
+	}
+}
+
 func TestRangeAggregationWithMetaData(t *testing.T) {
 	agg := NewRangeAggregation().Field("price").Meta(map[string]interface{}{"name": "Oliver"})
 	agg = agg.AddRange(nil, 50)
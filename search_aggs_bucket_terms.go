@@ -140,6 +140,9 @@ func (a *TermsAggregation) ValueType(valueType string) *TermsAggregation {
 	return a
 }
 
+// Order adds an ordering criterion for the buckets. Criteria added with
+// Order and the OrderBy... methods accumulate and are sent in the order
+// they were added, e.g. to break ties of a metric by document count.
 func (a *TermsAggregation) Order(order string, asc bool) *TermsAggregation {
 	a.order = append(a.order, TermsOrder{Field: order, Ascending: asc})
 	return a
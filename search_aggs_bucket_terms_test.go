@@ -68,6 +68,29 @@ func TestTermsAggregationWithMultipleSubAggregation(t *testing.T) {
 	}
 }
 
+func TestTermsAggregationWithMultipleOrders(t *testing.T) {
+	agg := NewTermsAggregation().Field("gender").
+		OrderByAggregation("avg_height", false).
+		OrderByCount(true).
+		OrderByAggregationAndMetric("height_stats", "max", false).
+		OrderByKeyAsc()
+	agg = agg.SubAggregation("avg_height", NewAvgAggregation().Field("height"))
+	agg = agg.SubAggregation("height_stats", NewStatsAggregation().Field("height"))
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"avg_height":{"avg":{"field":"height"}},"height_stats":{"stats":{"field":"height"}}},"terms":{"field":"gender","order":[{"avg_height":"desc"},{"_count":"asc"},{"height_stats.max":"desc"},{"_key":"asc"}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestTermsAggregationWithMetaData(t *testing.T) {
 	agg := NewTermsAggregation().Field("gender").Size(10).OrderByKeyDesc()
 	agg = agg.Meta(map[string]interface{}{"name": "Oliver"})
@@ -103,6 +126,29 @@ func TestTermsAggregationWithMissing(t *testing.T) {
 	}
 }
 
+func TestTermsAggregationWithDocCountOptions(t *testing.T) {
+	agg := NewTermsAggregation().Field("tags").
+		Missing(0).
+		MinDocCount(2).
+		ShardMinDocCount(1).
+		ShardSize(25).
+		ShowTermDocCountError(true).
+		CollectionMode("breadth_first")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"terms":{"collect_mode":"breadth_first","field":"tags","min_doc_count":2,"missing":0,"shard_min_doc_count":1,"shard_size":25,"show_term_doc_count_error":true}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestTermsAggregationWithIncludeExclude(t *testing.T) {
 	agg := NewTermsAggregation().Field("tags").Include(".*sport.*").Exclude("water_.*")
 	src, err := agg.Source()
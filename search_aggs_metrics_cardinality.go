@@ -41,6 +41,8 @@ func (a *CardinalityAggregation) Format(format string) *CardinalityAggregation {
 	return a
 }
 
+// Missing specifies the value to use for documents that have no value
+// in the field.
 func (a *CardinalityAggregation) Missing(missing interface{}) *CardinalityAggregation {
 	a.missing = missing
 	return a
@@ -56,11 +58,17 @@ func (a *CardinalityAggregation) Meta(metaData map[string]interface{}) *Cardinal
 	return a
 }
 
+// PrecisionThreshold sets the count below which counts are expected to be
+// close to accurate. Above this value, counts might become a bit more fuzzy.
+// Higher values use more memory. The maximum supported value is 40000,
+// the default is 3000.
 func (a *CardinalityAggregation) PrecisionThreshold(threshold int64) *CardinalityAggregation {
 	a.precisionThreshold = &threshold
 	return a
 }
 
+// Rehash specifies whether the values of a field that already holds
+// hashes (e.g. of type murmur3) should be hashed again.
 func (a *CardinalityAggregation) Rehash(rehash bool) *CardinalityAggregation {
 	a.rehash = &rehash
 	return a
@@ -5,6 +5,7 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
 	"testing"
 )
@@ -47,6 +48,23 @@ func TestCardinalityAggregationWithOptions(t *testing.T) {
 	}
 }
 
+func TestCardinalityAggregationWithMaxPrecisionThreshold(t *testing.T) {
+	agg := NewCardinalityAggregation().Field("user").PrecisionThreshold(40000)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"cardinality":{"field":"user","precision_threshold":40000}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestCardinalityAggregationWithFormat(t *testing.T) {
 	agg := NewCardinalityAggregation().Field("author.hash").Format("00000")
 	src, err := agg.Source()
@@ -80,3 +98,36 @@ func TestCardinalityAggregationWithMetaData(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestCardinalityAggregationWithPrecisionThresholdIntegration(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	searchResult, err := client.Search().
+		Index(testIndexName).
+		Query(NewMatchAllQuery()).
+		Size(0).
+		Aggregation("users_low", NewCardinalityAggregation().Field("user").PrecisionThreshold(1)).
+		Aggregation("users_high", NewCardinalityAggregation().Field("user").PrecisionThreshold(40000)).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	low, found := searchResult.Aggregations.Cardinality("users_low")
+	if !found || low == nil || low.Value == nil {
+		t.Fatalf("expected cardinality for %q; got: %v", "users_low", low)
+	}
+	high, found := searchResult.Aggregations.Cardinality("users_high")
+	if !found || high == nil || high.Value == nil {
+		t.Fatalf("expected cardinality for %q; got: %v", "users_high", high)
+	}
+
+	// Both are approximations, but with a high precision threshold the
+	// count of a small number of distinct values is accurate
+	if want, have := 2.0, *high.Value; want != have {
+		t.Errorf("expected cardinality %v with high precision threshold; got: %v", want, have)
+	}
+	if *low.Value <= 0 {
+		t.Errorf("expected cardinality > 0 with low precision threshold; got: %v", *low.Value)
+	}
+}
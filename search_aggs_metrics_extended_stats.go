@@ -14,6 +14,7 @@ type ExtendedStatsAggregation struct {
 	script          *Script
 	format          string
 	missing         interface{}
+	sigma           *float64
 	subAggregations map[string]Aggregation
 	meta            map[string]interface{}
 }
@@ -44,6 +45,13 @@ func (a *ExtendedStatsAggregation) Missing(missing interface{}) *ExtendedStatsAg
 	return a
 }
 
+// Sigma controls how many standard deviations +/- from the mean
+// should be returned in std_deviation_bounds (default: 2).
+func (a *ExtendedStatsAggregation) Sigma(sigma float64) *ExtendedStatsAggregation {
+	a.sigma = &sigma
+	return a
+}
+
 func (a *ExtendedStatsAggregation) SubAggregation(name string, subAggregation Aggregation) *ExtendedStatsAggregation {
 	a.subAggregations[name] = subAggregation
 	return a
@@ -85,6 +93,9 @@ func (a *ExtendedStatsAggregation) Source() (interface{}, error) {
 	if a.missing != nil {
 		opts["missing"] = a.missing
 	}
+	if a.sigma != nil {
+		opts["sigma"] = *a.sigma
+	}
 
 	// AggregationBuilder (SubAggregations)
 	if len(a.subAggregations) > 0 {
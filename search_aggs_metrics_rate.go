@@ -0,0 +1,119 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// RateAggregation is a single-value metrics aggregation that calculates
+// a rate of documents or a field in each date_histogram bucket.
+// It can only be used inside a date_histogram aggregation and is
+// supported as of Elasticsearch 7.10.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.10/search-aggregations-metrics-rate-aggregation.html
+type RateAggregation struct {
+	field  string
+	script *Script
+	unit   string
+	mode   string
+	format string
+	meta   map[string]interface{}
+}
+
+// NewRateAggregation creates a new RateAggregation.
+func NewRateAggregation() *RateAggregation {
+	return &RateAggregation{}
+}
+
+// Field is the field to sum or count per time unit. If it is not set,
+// the rate of documents is calculated.
+func (a *RateAggregation) Field(field string) *RateAggregation {
+	a.field = field
+	return a
+}
+
+// Script computes the values to sum or count per time unit.
+func (a *RateAggregation) Script(script *Script) *RateAggregation {
+	a.script = script
+	return a
+}
+
+// Unit is the time unit the rate is calculated for, i.e. one of
+// "second", "minute", "hour", "day", "week", "month", "quarter",
+// or "year". It defaults to the interval of the parent date_histogram.
+func (a *RateAggregation) Unit(unit string) *RateAggregation {
+	a.unit = unit
+	return a
+}
+
+// Mode specifies how the field values are accumulated, i.e. either
+// "sum" (default) or "value_count".
+func (a *RateAggregation) Mode(mode string) *RateAggregation {
+	a.mode = mode
+	return a
+}
+
+// Format to use when formatting the value in the response.
+func (a *RateAggregation) Format(format string) *RateAggregation {
+	a.format = format
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *RateAggregation) Meta(metaData map[string]interface{}) *RateAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the JSON-serializable data.
+func (a *RateAggregation) Source() (interface{}, error) {
+	// Example:
+	//	{
+	//    "aggs" : {
+	//      "my_rate" : { "rate" : { "unit" : "month" } }
+	//    }
+	//	}
+	// This method returns only the { "rate" : { "unit" : "month" } } part.
+
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["rate"] = opts
+
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.unit != "" {
+		switch a.unit {
+		case "second", "minute", "hour", "day", "week", "month", "quarter", "year":
+		default:
+			return nil, fmt.Errorf("elastic: invalid unit %q for rate aggregation", a.unit)
+		}
+		opts["unit"] = a.unit
+	}
+	if a.mode != "" {
+		switch a.mode {
+		case "sum", "value_count":
+		default:
+			return nil, fmt.Errorf("elastic: invalid mode %q for rate aggregation", a.mode)
+		}
+		opts["mode"] = a.mode
+	}
+	if a.format != "" {
+		opts["format"] = a.format
+	}
+
+	// Add Meta data if available
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRateAggregation(t *testing.T) {
+	agg := NewRateAggregation().Unit("month")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"rate":{"unit":"month"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRateAggregationWithField(t *testing.T) {
+	agg := NewRateAggregation().Field("price").Unit("year").Mode("value_count")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"rate":{"field":"price","mode":"value_count","unit":"year"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRateAggregationWithInvalidUnitOrMode(t *testing.T) {
+	if _, err := NewRateAggregation().Unit("fortnight").Source(); err == nil {
+		t.Fatal("expected error for invalid unit")
+	}
+	if _, err := NewRateAggregation().Field("price").Mode("avg").Source(); err == nil {
+		t.Fatal("expected error for invalid mode")
+	}
+}
@@ -16,6 +16,7 @@ package elastic
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-aggregations-metrics-top-hits-aggregation.html
 type TopHitsAggregation struct {
 	searchSource *SearchSource
+	meta         map[string]interface{}
 }
 
 func NewTopHitsAggregation() *TopHitsAggregation {
@@ -118,6 +119,12 @@ func (a *TopHitsAggregation) Highlighter() *Highlight {
 	return a.searchSource.Highlighter()
 }
 
+// Meta sets the meta data to be included in the aggregation response.
+func (a *TopHitsAggregation) Meta(metaData map[string]interface{}) *TopHitsAggregation {
+	a.meta = metaData
+	return a
+}
+
 func (a *TopHitsAggregation) Source() (interface{}, error) {
 	// Example:
 	// {
@@ -149,5 +156,11 @@ func (a *TopHitsAggregation) Source() (interface{}, error) {
 		return nil, err
 	}
 	source["top_hits"] = src
+
+	// Add Meta data if available
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
 	return source, nil
 }
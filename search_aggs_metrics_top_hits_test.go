@@ -29,3 +29,20 @@ func TestTopHitsAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestTopHitsAggregationWithMetaData(t *testing.T) {
+	agg := NewTopHitsAggregation().Size(1).Meta(map[string]interface{}{"label": "Latest"})
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"meta":{"label":"Latest"},"top_hits":{"size":1}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
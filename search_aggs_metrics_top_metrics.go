@@ -0,0 +1,100 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// TopMetricsAggregation selects metrics from the document with the largest
+// or smallest "sort" value. It is similar to the top_hits aggregation, but
+// it is faster and uses less memory as it doesn't load the documents.
+// It is supported as of Elasticsearch 7.7.
+//
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.7/search-aggregations-metrics-top-metrics.html
+type TopMetricsAggregation struct {
+	fields []string
+	sorter Sorter
+	size   *int
+	meta   map[string]interface{}
+}
+
+// NewTopMetricsAggregation creates a new TopMetricsAggregation.
+func NewTopMetricsAggregation() *TopMetricsAggregation {
+	return &TopMetricsAggregation{}
+}
+
+// Metric adds a field to return the value of for the top documents.
+func (a *TopMetricsAggregation) Metric(field string) *TopMetricsAggregation {
+	a.fields = append(a.fields, field)
+	return a
+}
+
+// Sort specifies how the top documents are determined, e.g.
+// NewFieldSort("timestamp").Desc().
+func (a *TopMetricsAggregation) Sort(sorter Sorter) *TopMetricsAggregation {
+	a.sorter = sorter
+	return a
+}
+
+// Size is the number of top documents to return the metrics of (default: 1).
+func (a *TopMetricsAggregation) Size(size int) *TopMetricsAggregation {
+	a.size = &size
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *TopMetricsAggregation) Meta(metaData map[string]interface{}) *TopMetricsAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Source returns the a JSON-serializable interface.
+func (a *TopMetricsAggregation) Source() (interface{}, error) {
+	// Example:
+	// {
+	//   "aggs": {
+	//     "tm": {
+	//       "top_metrics": {
+	//         "metrics": [{"field": "m"}],
+	//         "sort": {"s": "desc"},
+	//         "size": 1
+	//       }
+	//     }
+	//   }
+	// }
+	// This method returns only the { "top_metrics" : { ... } } part.
+
+	if len(a.fields) == 0 {
+		return nil, errors.New("elastic: TopMetricsAggregation requires at least one Metric")
+	}
+	if a.sorter == nil {
+		return nil, errors.New("elastic: TopMetricsAggregation requires a Sort")
+	}
+
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["top_metrics"] = opts
+
+	var metrics []interface{}
+	for _, field := range a.fields {
+		metrics = append(metrics, map[string]interface{}{"field": field})
+	}
+	opts["metrics"] = metrics
+
+	src, err := a.sorter.Source()
+	if err != nil {
+		return nil, err
+	}
+	opts["sort"] = src
+
+	if a.size != nil {
+		opts["size"] = *a.size
+	}
+
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTopMetricsAggregation(t *testing.T) {
+	agg := NewTopMetricsAggregation().
+		Metric("price").
+		Sort(NewFieldSort("timestamp").Desc())
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"top_metrics":{"metrics":[{"field":"price"}],"sort":{"timestamp":{"order":"desc"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestTopMetricsAggregationWithOptions(t *testing.T) {
+	agg := NewTopMetricsAggregation().
+		Metric("price").
+		Metric("quantity").
+		Sort(NewFieldSort("timestamp").Asc()).
+		Size(3).
+		Meta(map[string]interface{}{"name": "Oliver"})
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"meta":{"name":"Oliver"},"top_metrics":{"metrics":[{"field":"price"},{"field":"quantity"}],"size":3,"sort":{"timestamp":{"order":"asc"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestTopMetricsAggregationValidate(t *testing.T) {
+	if _, err := NewTopMetricsAggregation().Sort(NewFieldSort("timestamp")).Source(); err == nil {
+		t.Error("expected error for missing metric; got: nil")
+	}
+	if _, err := NewTopMetricsAggregation().Metric("price").Source(); err == nil {
+		t.Error("expected error for missing sort; got: nil")
+	}
+}
@@ -1619,6 +1619,34 @@ func TestAggsMetricsValueCount(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsRate(t *testing.T) {
+	s := `{
+	"my_rate": {
+		"value": 550.0
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Rate("my_rate")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.Value == nil {
+		t.Fatalf("expected aggregation value != nil; got: %v", agg.Value)
+	}
+	if *agg.Value != float64(550) {
+		t.Fatalf("expected aggregation value = %v; got: %v", float64(550), *agg.Value)
+	}
+}
+
 func TestAggsMetricsCardinality(t *testing.T) {
 	s := `{
 	"author_count": {
@@ -1774,6 +1802,81 @@ func TestAggsMetricsExtendedStats(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsExtendedStatsWithBounds(t *testing.T) {
+	s := `{
+	"grades_stats": {
+		"count": 2,
+		"min": 50.0,
+		"max": 100.0,
+		"avg": 75.0,
+		"sum": 150.0,
+		"sum_of_squares": 12500.0,
+		"variance": 625.0,
+		"variance_population": 625.0,
+		"variance_sampling": 1250.0,
+		"std_deviation": 25.0,
+		"std_deviation_population": 25.0,
+		"std_deviation_sampling": 35.35533905932738,
+		"std_deviation_bounds": {
+			"upper": 125.0,
+			"lower": 25.0,
+			"upper_population": 125.0,
+			"lower_population": 25.0,
+			"upper_sampling": 145.71067811865476,
+			"lower_sampling": 4.289321881345245
+		}
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.ExtendedStats("grades_stats")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.VariancePopulation == nil || *agg.VariancePopulation != 625.0 {
+		t.Errorf("expected variance_population = %v; got: %v", 625.0, agg.VariancePopulation)
+	}
+	if agg.VarianceSampling == nil || *agg.VarianceSampling != 1250.0 {
+		t.Errorf("expected variance_sampling = %v; got: %v", 1250.0, agg.VarianceSampling)
+	}
+	if agg.StdDeviationPopulation == nil || *agg.StdDeviationPopulation != 25.0 {
+		t.Errorf("expected std_deviation_population = %v; got: %v", 25.0, agg.StdDeviationPopulation)
+	}
+	if agg.StdDeviationSampling == nil || *agg.StdDeviationSampling != 35.35533905932738 {
+		t.Errorf("expected std_deviation_sampling = %v; got: %v", 35.35533905932738, agg.StdDeviationSampling)
+	}
+	bounds := agg.StdDeviationBounds
+	if bounds == nil {
+		t.Fatalf("expected std_deviation_bounds != nil; got: %v", bounds)
+	}
+	if bounds.Upper == nil || *bounds.Upper != 125.0 {
+		t.Errorf("expected std_deviation_bounds.upper = %v; got: %v", 125.0, bounds.Upper)
+	}
+	if bounds.Lower == nil || *bounds.Lower != 25.0 {
+		t.Errorf("expected std_deviation_bounds.lower = %v; got: %v", 25.0, bounds.Lower)
+	}
+	if bounds.UpperPopulation == nil || *bounds.UpperPopulation != 125.0 {
+		t.Errorf("expected std_deviation_bounds.upper_population = %v; got: %v", 125.0, bounds.UpperPopulation)
+	}
+	if bounds.LowerPopulation == nil || *bounds.LowerPopulation != 25.0 {
+		t.Errorf("expected std_deviation_bounds.lower_population = %v; got: %v", 25.0, bounds.LowerPopulation)
+	}
+	if bounds.UpperSampling == nil || *bounds.UpperSampling != 145.71067811865476 {
+		t.Errorf("expected std_deviation_bounds.upper_sampling = %v; got: %v", 145.71067811865476, bounds.UpperSampling)
+	}
+	if bounds.LowerSampling == nil || *bounds.LowerSampling != 4.289321881345245 {
+		t.Errorf("expected std_deviation_bounds.lower_sampling = %v; got: %v", 4.289321881345245, bounds.LowerSampling)
+	}
+}
+
 func TestAggsMatrixStats(t *testing.T) {
 	s := `{
 	"matrixstats": {
@@ -2138,6 +2241,52 @@ func TestAggsMetricsTopHits(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsTopMetrics(t *testing.T) {
+	s := `{
+	"tm": {
+		"top": [
+			{"sort": ["2020-05-04T12:00:00.000Z"], "metrics": {"price": 9.99, "product": "apple"}},
+			{"sort": ["2020-05-03T12:00:00.000Z"], "metrics": {"price": 1.99, "product": null}}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.TopMetrics("tm")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if want, have := 2, len(agg.Top); want != have {
+		t.Fatalf("expected %d top entries; got: %d", want, have)
+	}
+	if want, have := 1, len(agg.Top[0].Sort); want != have {
+		t.Fatalf("expected %d sort values; got: %d", want, have)
+	}
+	if want, have := "2020-05-04T12:00:00.000Z", agg.Top[0].Sort[0]; want != have {
+		t.Errorf("expected sort value %v; got: %v", want, have)
+	}
+	if want, have := 9.99, agg.Top[0].Metrics["price"]; want != have {
+		t.Errorf("expected price %v; got: %v", want, have)
+	}
+	if want, have := "apple", agg.Top[0].Metrics["product"]; want != have {
+		t.Errorf("expected product %v; got: %v", want, have)
+	}
+	if want, have := 1.99, agg.Top[1].Metrics["price"]; want != have {
+		t.Errorf("expected price %v; got: %v", want, have)
+	}
+	if v, found := agg.Top[1].Metrics["product"]; !found || v != nil {
+		t.Errorf("expected product to be nil; got: %v (found=%v)", v, found)
+	}
+}
+
 func TestAggsBucketGlobal(t *testing.T) {
 	s := `{
 	"all_products" : {
@@ -2348,6 +2497,68 @@ func TestAggsBucketFiltersWithNamedBuckets(t *testing.T) {
 	}
 }
 
+func TestAggsBucketFiltersWithOtherBucket(t *testing.T) {
+	s := `{
+  "messages" : {
+    "buckets" : {
+      "errors" : {
+        "doc_count" : 34
+      },
+      "warnings" : {
+        "doc_count" : 439
+      },
+      "_other_" : {
+        "doc_count" : 12
+      }
+    }
+  }
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Filters("messages")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.NamedBuckets) != 3 {
+		t.Fatalf("expected %d buckets; got: %d", 3, len(agg.NamedBuckets))
+	}
+	other, found := agg.OtherBucket("")
+	if !found {
+		t.Fatalf("expected other bucket to be found; got: %v", found)
+	}
+	if other.DocCount != 12 {
+		t.Errorf("expected DocCount = %d; got: %d", 12, other.DocCount)
+	}
+	if _, found := agg.OtherBucket("other_messages"); found {
+		t.Errorf("expected no other bucket with key %q; got: %v", "other_messages", found)
+	}
+
+	// Unnamed filters return the other bucket as the last element of Buckets
+	s = `{"messages":{"buckets":[{"doc_count":34},{"doc_count":439},{"doc_count":12}]}}`
+	aggs = new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	agg, found = aggs.Filters("messages")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if _, found := agg.OtherBucket(""); found {
+		t.Errorf("expected OtherBucket to be scoped to named filters; got: %v", found)
+	}
+	if want, have := int64(12), agg.Buckets[len(agg.Buckets)-1].DocCount; want != have {
+		t.Errorf("expected DocCount = %d; got: %d", want, have)
+	}
+}
+
 func TestAggsBucketAdjacencyMatrix(t *testing.T) {
 	s := `{
 	"interactions": {
@@ -2578,6 +2789,99 @@ func TestAggsBucketTerms(t *testing.T) {
 	}
 }
 
+func TestAggsBucketTermsWithDocCountError(t *testing.T) {
+	s := `{
+	"users" : {
+	  "doc_count_error_upper_bound" : 3,
+	  "sum_other_doc_count" : 12,
+	  "buckets" : [ {
+	    "key" : "olivere",
+	    "doc_count" : 5,
+	    "doc_count_error_upper_bound" : 2
+	  }, {
+	    "key" : "sandrae",
+	    "doc_count" : 4,
+	    "doc_count_error_upper_bound" : 0
+	  } ]
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Terms("users")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if want, have := int64(3), agg.DocCountErrorUpperBound; want != have {
+		t.Errorf("expected doc_count_error_upper_bound %d; got: %d", want, have)
+	}
+	if want, have := int64(12), agg.SumOfOtherDocCount; want != have {
+		t.Errorf("expected sum_other_doc_count %d; got: %d", want, have)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d bucket entries; got: %d", 2, len(agg.Buckets))
+	}
+	if want, have := int64(2), agg.Buckets[0].DocCountErrorUpperBound; want != have {
+		t.Errorf("expected bucket doc_count_error_upper_bound %d; got: %d", want, have)
+	}
+	if want, have := int64(0), agg.Buckets[1].DocCountErrorUpperBound; want != have {
+		t.Errorf("expected bucket doc_count_error_upper_bound %d; got: %d", want, have)
+	}
+}
+
+func TestAggsBucketTermsWithMetaData(t *testing.T) {
+	meta := map[string]interface{}{"label": "Users", "chart": "bar"}
+
+	// The meta data is sent with the aggregation...
+	src, err := NewTermsAggregation().Field("user").Meta(meta).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{"meta":{"chart":"bar","label":"Users"},"terms":{"field":"user"}}`, string(data); want != have {
+		t.Fatalf("expected\n%s\n,got:\n%s", want, have)
+	}
+
+	// ... and echoed by Elasticsearch in the response
+	s := `{
+	"users" : {
+	  "meta" : {"chart":"bar","label":"Users"},
+	  "doc_count_error_upper_bound" : 0,
+	  "sum_other_doc_count" : 0,
+	  "buckets" : [ {
+	    "key" : "olivere",
+	    "doc_count" : 2
+	  } ]
+	}
+}`
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	agg, found := aggs.Terms("users")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if want, have := len(meta), len(agg.Meta); want != have {
+		t.Fatalf("expected %d meta data keys; got: %d", want, have)
+	}
+	for k, v := range meta {
+		if want, have := v, agg.Meta[k]; want != have {
+			t.Errorf("expected meta data %q = %v; got: %v", k, want, have)
+		}
+	}
+}
+
 func TestAggsBucketTermsWithNumericKeys(t *testing.T) {
 	s := `{
 	"users" : {
@@ -3171,6 +3475,73 @@ func TestAggsBucketDateHistogram(t *testing.T) {
 	}
 }
 
+func TestAggsBucketDateHistogramWithKeyedResponse(t *testing.T) {
+	s := `{
+	"articles_over_time": {
+	  "buckets": {
+	      "2013-03-02": {
+	          "key_as_string": "2013-03-02",
+	          "key": 1330646400000,
+	          "doc_count": 2
+	      },
+	      "2013-02-02": {
+	          "key_as_string": "2013-02-02",
+	          "key": 1328140800000,
+	          "doc_count": 1
+	      }
+	  }
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	// Keyed accessor
+	keyed, found := aggs.KeyedDateHistogram("articles_over_time")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if keyed == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", keyed)
+	}
+	if len(keyed.Buckets) != 2 {
+		t.Fatalf("expected %d bucket entries; got: %d", 2, len(keyed.Buckets))
+	}
+	if bucket := keyed.Buckets["2013-02-02"]; bucket == nil || bucket.DocCount != 1 {
+		t.Errorf("expected bucket %q with doc count %d; got: %+v", "2013-02-02", 1, bucket)
+	}
+	if bucket := keyed.Buckets["2013-03-02"]; bucket == nil || bucket.DocCount != 2 {
+		t.Errorf("expected bucket %q with doc count %d; got: %+v", "2013-03-02", 2, bucket)
+	}
+
+	// The list accessor returns the keyed buckets sorted by key
+	agg, found := aggs.DateHistogram("articles_over_time")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d bucket entries; got: %d", 2, len(agg.Buckets))
+	}
+	if agg.Buckets[0].Key != 1328140800000 {
+		t.Errorf("expected key %v; got: %v", 1328140800000, agg.Buckets[0].Key)
+	}
+	if agg.Buckets[0].DocCount != 1 {
+		t.Errorf("expected doc count %d; got: %d", 1, agg.Buckets[0].DocCount)
+	}
+	if agg.Buckets[1].Key != 1330646400000 {
+		t.Errorf("expected key %v; got: %v", 1330646400000, agg.Buckets[1].Key)
+	}
+	if agg.Buckets[1].DocCount != 2 {
+		t.Errorf("expected doc count %d; got: %d", 2, agg.Buckets[1].DocCount)
+	}
+}
+
 func TestAggsMetricsGeoBounds(t *testing.T) {
 	s := `{
   "viewport": {
@@ -3263,6 +3634,52 @@ func TestAggsBucketGeoHash(t *testing.T) {
 	}
 }
 
+func TestAggsBucketGeoHexGrid(t *testing.T) {
+	s := `{
+	"large-grid": {
+		"buckets": [
+			{
+				"key": "841969dffffffff",
+				"doc_count": 3
+			},
+			{
+				"key": "841fb47ffffffff",
+				"doc_count": 2
+			}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.GeoHexGrid("large-grid")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d bucket entries; got: %d", 2, len(agg.Buckets))
+	}
+	if agg.Buckets[0].Key != "841969dffffffff" {
+		t.Errorf("expected key %q; got: %q", "841969dffffffff", agg.Buckets[0].Key)
+	}
+	if agg.Buckets[0].DocCount != 3 {
+		t.Errorf("expected doc count %d; got: %d", 3, agg.Buckets[0].DocCount)
+	}
+	if agg.Buckets[1].Key != "841fb47ffffffff" {
+		t.Errorf("expected key %q; got: %q", "841fb47ffffffff", agg.Buckets[1].Key)
+	}
+	if agg.Buckets[1].DocCount != 2 {
+		t.Errorf("expected doc count %d; got: %d", 2, agg.Buckets[1].DocCount)
+	}
+}
+
 func TestAggsMetricsGeoCentroid(t *testing.T) {
 	s := `{
   "centroid": {
@@ -3993,3 +4410,78 @@ func TestAggsComposite(t *testing.T) {
 		t.Fatalf("expected to find bucket key value %v; got: %v", want, have)
 	}
 }
+
+func TestAggsCompositeNextAfterKey(t *testing.T) {
+	s := `{
+	"the_composite" : {
+		"after_key" : {
+			"composite_users" : "sandrae",
+			"composite_retweets" : 12.0
+		},
+		"buckets" : [
+		  {
+			"key" : {
+			  "composite_users" : "sandrae",
+			  "composite_retweets" : 12.0
+			},
+			"doc_count" : 1
+		  }
+		]
+	},
+	"the_last_page" : {
+		"buckets" : []
+	}
+	}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Composite("the_composite")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	afterKey, found := agg.NextAfterKey()
+	if !found {
+		t.Fatalf("expected after key to be found; got: %v", found)
+	}
+	if want, have := "sandrae", afterKey["composite_users"]; want != have {
+		t.Fatalf("expected after key composite_users = %v; got: %v", want, have)
+	}
+	if want, have := 12.0, afterKey["composite_retweets"]; want != have {
+		t.Fatalf("expected after key composite_retweets = %v; got: %v", want, have)
+	}
+
+	// Feed the after key into the aggregation for the next page
+	src, err := NewCompositeAggregation().
+		Sources(NewCompositeAggregationTermsValuesSource("composite_users").Field("user")).
+		AggregateAfter(afterKey).
+		Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"composite":{"after":{"composite_retweets":12,"composite_users":"sandrae"},"sources":[{"composite_users":{"terms":{"field":"user"}}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// Last page without after_key
+	agg, found = aggs.Composite("the_last_page")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	afterKey, found = agg.NextAfterKey()
+	if found {
+		t.Fatalf("expected no after key on last page; got: %v", found)
+	}
+	if afterKey != nil {
+		t.Fatalf("expected after key to be nil; got: %v", afterKey)
+	}
+}
@@ -0,0 +1,253 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrSkipAggregation can be returned from an AggregationWalkFunc to skip
+// the buckets and sub-aggregations of the current aggregation or bucket.
+var ErrSkipAggregation = errors.New("skip this aggregation")
+
+// AggregationWalkFunc is called by Aggregations.Walk for every bucket and
+// metric in the aggregation results.
+//
+// The path starts with the name of the top-level aggregation, followed by
+// the key of the bucket (if any), the name of the sub-aggregation, and so on.
+// Keys that are objects, e.g. of composite buckets, are rendered as JSON
+// with sorted keys. Response fields like meta are not visited.
+// For buckets, value is the document count (int64). For single-value metrics
+// like avg or sum, value is the metric value (float64, or nil if missing).
+// For multi-value metrics like stats or percentiles, value is the decoded
+// metric as a map[string]interface{}.
+//
+// If the function returns ErrSkipAggregation, the buckets and
+// sub-aggregations below path are skipped. Any other error stops the walk
+// and is returned by Walk.
+type AggregationWalkFunc func(path []string, value interface{}) error
+
+// Walk traverses the aggregation results depth-first and calls fn for
+// every bucket and metric. Aggregations on the same level are visited
+// in lexical order of their names, buckets are visited in the order
+// returned by Elasticsearch.
+func (a Aggregations) Walk(fn AggregationWalkFunc) error {
+	err := walkAggregations(a, nil, fn)
+	if err == ErrSkipAggregation {
+		return nil
+	}
+	return err
+}
+
+// walkAggregations walks the named aggregations below path.
+func walkAggregations(aggs map[string]*json.RawMessage, path []string, fn AggregationWalkFunc) error {
+	names := make([]string, 0, len(aggs))
+	for name := range aggs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		raw := aggs[name]
+		if raw == nil {
+			continue
+		}
+		var agg map[string]*json.RawMessage
+		if err := decodeAggregationJSON(*raw, &agg); err != nil {
+			// Not an aggregation, e.g. a scalar field in a bucket
+			continue
+		}
+		if err := walkAggregation(name, agg, append(path, name), fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkAggregation walks a single aggregation with the given path.
+func walkAggregation(name string, agg map[string]*json.RawMessage, path []string, fn AggregationWalkFunc) error {
+	// Multi-bucket aggregation
+	if raw, found := agg["buckets"]; found && raw != nil {
+		return walkBuckets(*raw, path, fn)
+	}
+
+	// Single-bucket aggregation, e.g. filter or nested
+	if raw, found := agg["doc_count"]; found && raw != nil {
+		var docCount int64
+		if err := json.Unmarshal(*raw, &docCount); err != nil {
+			return fmt.Errorf("elastic: invalid doc_count in aggregation %q: %v", name, err)
+		}
+		if err := fn(copyPath(path), docCount); err != nil {
+			if err == ErrSkipAggregation {
+				return nil
+			}
+			return err
+		}
+		return walkAggregations(subAggregations(agg), path, fn)
+	}
+
+	// Single-value metric
+	if raw, found := agg["value"]; found {
+		var value *float64
+		if raw != nil {
+			if err := json.Unmarshal(*raw, &value); err != nil {
+				// e.g. a scripted metric with a non-numeric value
+				var v interface{}
+				if err := decodeAggregationJSON(*raw, &v); err != nil {
+					return err
+				}
+				return skipToNil(fn(copyPath(path), v))
+			}
+		}
+		if value == nil {
+			return skipToNil(fn(copyPath(path), nil))
+		}
+		return skipToNil(fn(copyPath(path), *value))
+	}
+
+	// Multi-value metric
+	metric := make(map[string]interface{})
+	for k, raw := range agg {
+		if k == "meta" {
+			continue
+		}
+		if raw == nil {
+			metric[k] = nil
+			continue
+		}
+		var v interface{}
+		if err := decodeAggregationJSON(*raw, &v); err != nil {
+			return err
+		}
+		metric[k] = v
+	}
+	return skipToNil(fn(copyPath(path), metric))
+}
+
+// walkBuckets walks the buckets of a multi-bucket aggregation. The buckets
+// can either be an array or, for keyed responses, an object.
+func walkBuckets(data []byte, path []string, fn AggregationWalkFunc) error {
+	var list []map[string]*json.RawMessage
+	if err := decodeAggregationJSON(data, &list); err == nil {
+		for _, bucket := range list {
+			if err := walkBucket(bucketKey(bucket), bucket, path, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var keyed map[string]map[string]*json.RawMessage
+	if err := decodeAggregationJSON(data, &keyed); err != nil {
+		return fmt.Errorf("elastic: invalid buckets in aggregation %v: %v", path, err)
+	}
+	keys := make([]string, 0, len(keyed))
+	for key := range keyed {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := walkBucket(key, keyed[key], path, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// walkBucket walks a single bucket and its sub-aggregations.
+func walkBucket(key string, bucket map[string]*json.RawMessage, path []string, fn AggregationWalkFunc) error {
+	path = append(path, key)
+	var docCount int64
+	if raw, found := bucket["doc_count"]; found && raw != nil {
+		if err := json.Unmarshal(*raw, &docCount); err != nil {
+			return fmt.Errorf("elastic: invalid doc_count in bucket %v: %v", path, err)
+		}
+	}
+	if err := fn(copyPath(path), docCount); err != nil {
+		if err == ErrSkipAggregation {
+			return nil
+		}
+		return err
+	}
+	return walkAggregations(subAggregations(bucket), path, fn)
+}
+
+// bucketKey returns a string representation of the key of a bucket.
+// It prefers key_as_string over key, as returned e.g. by date histograms.
+func bucketKey(bucket map[string]*json.RawMessage) string {
+	for _, field := range []string{"key_as_string", "key"} {
+		raw, found := bucket[field]
+		if !found || raw == nil {
+			continue
+		}
+		var key interface{}
+		if err := decodeAggregationJSON(*raw, &key); err != nil {
+			continue
+		}
+		switch key.(type) {
+		case map[string]interface{}, []interface{}:
+			// e.g. the key of a composite bucket; render it as JSON
+			// with sorted keys
+			data, err := json.Marshal(key)
+			if err != nil {
+				continue
+			}
+			return string(data)
+		}
+		return fmt.Sprint(key)
+	}
+	return ""
+}
+
+// reservedAggregationKeys are the fields of aggregation and bucket
+// responses that are never sub-aggregations.
+var reservedAggregationKeys = map[string]bool{
+	"key":                         true,
+	"key_as_string":               true,
+	"meta":                        true,
+	"after_key":                   true,
+	"buckets":                     true,
+	"doc_count_error_upper_bound": true,
+	"sum_other_doc_count":         true,
+}
+
+// subAggregations returns the entries of a bucket that are objects,
+// i.e. the sub-aggregations.
+func subAggregations(bucket map[string]*json.RawMessage) map[string]*json.RawMessage {
+	aggs := make(map[string]*json.RawMessage)
+	for k, raw := range bucket {
+		if raw == nil || reservedAggregationKeys[k] {
+			continue
+		}
+		if data := bytes.TrimSpace(*raw); len(data) > 0 && data[0] == '{' {
+			aggs[k] = raw
+		}
+	}
+	return aggs
+}
+
+// decodeAggregationJSON decodes data into v, preserving numbers
+// as json.Number.
+func decodeAggregationJSON(data []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
+
+func skipToNil(err error) error {
+	if err == ErrSkipAggregation {
+		return nil
+	}
+	return err
+}
+
+func copyPath(path []string) []string {
+	p := make([]string, len(path))
+	copy(p, path)
+	return p
+}
@@ -0,0 +1,253 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAggregationsWalk(t *testing.T) {
+	s := `{
+	"users": {
+		"doc_count_error_upper_bound": 0,
+		"sum_other_doc_count": 0,
+		"buckets": [
+			{
+				"key": "olivere",
+				"doc_count": 2,
+				"by_month": {
+					"buckets": [
+						{
+							"key_as_string": "2018-01",
+							"key": 1514764800000,
+							"doc_count": 1,
+							"avg_retweets": { "value": 12.5 }
+						},
+						{
+							"key_as_string": "2018-02",
+							"key": 1517443200000,
+							"doc_count": 1,
+							"avg_retweets": { "value": null }
+						}
+					]
+				}
+			},
+			{
+				"key": "sandrae",
+				"doc_count": 1,
+				"by_month": {
+					"buckets": [
+						{
+							"key_as_string": "2018-01",
+							"key": 1514764800000,
+							"doc_count": 1,
+							"avg_retweets": { "value": 3 }
+						}
+					]
+				}
+			}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	var visited []string
+	values := make(map[string]interface{})
+	err := aggs.Walk(func(path []string, value interface{}) error {
+		key := strings.Join(path, "/")
+		visited = append(visited, key)
+		values[key] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		"users/olivere",
+		"users/olivere/by_month/2018-01",
+		"users/olivere/by_month/2018-01/avg_retweets",
+		"users/olivere/by_month/2018-02",
+		"users/olivere/by_month/2018-02/avg_retweets",
+		"users/sandrae",
+		"users/sandrae/by_month/2018-01",
+		"users/sandrae/by_month/2018-01/avg_retweets",
+	}
+	if !reflect.DeepEqual(expectedPaths, visited) {
+		t.Fatalf("expected paths\n%v\ngot:\n%v", expectedPaths, visited)
+	}
+
+	expectedValues := map[string]interface{}{
+		"users/olivere":                               int64(2),
+		"users/olivere/by_month/2018-01":              int64(1),
+		"users/olivere/by_month/2018-01/avg_retweets": float64(12.5),
+		"users/olivere/by_month/2018-02":              int64(1),
+		"users/olivere/by_month/2018-02/avg_retweets": nil,
+		"users/sandrae":                               int64(1),
+		"users/sandrae/by_month/2018-01":              int64(1),
+		"users/sandrae/by_month/2018-01/avg_retweets": float64(3),
+	}
+	if !reflect.DeepEqual(expectedValues, values) {
+		t.Fatalf("expected values\n%v\ngot:\n%v", expectedValues, values)
+	}
+}
+
+func TestAggregationsWalkComposite(t *testing.T) {
+	s := `{
+	"my_composite": {
+		"after_key": { "product": "b", "shop": "x" },
+		"buckets": [
+			{
+				"key": { "shop": "x", "product": "a" },
+				"doc_count": 3,
+				"avg_price": { "value": 10 }
+			},
+			{
+				"key": { "shop": "x", "product": "b" },
+				"doc_count": 1,
+				"avg_price": { "value": 20 }
+			}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	var visited []string
+	values := make(map[string]interface{})
+	err := aggs.Walk(func(path []string, value interface{}) error {
+		key := strings.Join(path, "|")
+		visited = append(visited, key)
+		values[key] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		`my_composite|{"product":"a","shop":"x"}`,
+		`my_composite|{"product":"a","shop":"x"}|avg_price`,
+		`my_composite|{"product":"b","shop":"x"}`,
+		`my_composite|{"product":"b","shop":"x"}|avg_price`,
+	}
+	if !reflect.DeepEqual(expectedPaths, visited) {
+		t.Fatalf("expected paths\n%v\ngot:\n%v", expectedPaths, visited)
+	}
+	if want, have := int64(3), values[`my_composite|{"product":"a","shop":"x"}`]; want != have {
+		t.Errorf("expected doc count %v; got: %v", want, have)
+	}
+	if want, have := float64(20), values[`my_composite|{"product":"b","shop":"x"}|avg_price`]; want != have {
+		t.Errorf("expected value %v; got: %v", want, have)
+	}
+}
+
+func TestAggregationsWalkWithMetaData(t *testing.T) {
+	s := `{
+	"recent": {
+		"meta": { "label": "Recent" },
+		"doc_count": 4,
+		"price_stats": {
+			"meta": { "label": "Price" },
+			"count": 4,
+			"min": 1,
+			"max": 5
+		}
+	}
+}`
+
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	var visited []string
+	values := make(map[string]interface{})
+	err := aggs.Walk(func(path []string, value interface{}) error {
+		key := strings.Join(path, "/")
+		visited = append(visited, key)
+		values[key] = value
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		"recent",
+		"recent/price_stats",
+	}
+	if !reflect.DeepEqual(expectedPaths, visited) {
+		t.Fatalf("expected paths\n%v\ngot:\n%v", expectedPaths, visited)
+	}
+	stats, ok := values["recent/price_stats"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected multi-value metric; got: %T", values["recent/price_stats"])
+	}
+	if _, found := stats["meta"]; found {
+		t.Errorf("expected meta to be omitted from metric; got: %v", stats)
+	}
+	if want, have := 3, len(stats); want != have {
+		t.Errorf("expected %d metric values; got: %v", want, stats)
+	}
+}
+
+func TestAggregationsWalkSkip(t *testing.T) {
+	s := `{
+	"users": {
+		"buckets": [
+			{ "key": "olivere", "doc_count": 2, "avg_retweets": { "value": 12.5 } },
+			{ "key": "sandrae", "doc_count": 1, "avg_retweets": { "value": 3 } }
+		]
+	},
+	"stats_retweets": { "count": 3, "min": 3, "max": 20, "avg": 9.33, "sum": 28 }
+}`
+
+	aggs := new(Aggregations)
+	if err := json.Unmarshal([]byte(s), &aggs); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	var visited []string
+	err := aggs.Walk(func(path []string, value interface{}) error {
+		visited = append(visited, strings.Join(path, "/"))
+		if len(path) == 2 && path[1] == "olivere" {
+			return ErrSkipAggregation
+		}
+		if path[0] == "stats_retweets" {
+			metric, ok := value.(map[string]interface{})
+			if !ok {
+				t.Fatalf("expected multi-value metric to be a map; got: %T", value)
+			}
+			if want, have := json.Number("28"), metric["sum"]; want != have {
+				t.Fatalf("expected sum = %v; got: %v", want, have)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedPaths := []string{
+		"stats_retweets",
+		"users/olivere",
+		"users/sandrae",
+		"users/sandrae/avg_retweets",
+	}
+	if !reflect.DeepEqual(expectedPaths, visited) {
+		t.Fatalf("expected paths\n%v\ngot:\n%v", expectedPaths, visited)
+	}
+}
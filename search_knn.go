@@ -0,0 +1,126 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KnnQuery is a k-nearest neighbor (kNN) search on a dense_vector field.
+// It is used as the top-level knn option of a search request, see
+// SearchSource.Knn. It is supported as of Elasticsearch 8.0.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/8.0/knn-search.html
+// for details.
+type KnnQuery struct {
+	field         string
+	queryVector   []float64
+	k             *int
+	numCandidates *int
+	filters       []Query
+	boost         *float64
+}
+
+// NewKnnQuery creates and initializes a new KnnQuery on the given
+// dense_vector field.
+func NewKnnQuery(field string) *KnnQuery {
+	return &KnnQuery{
+		field: field,
+	}
+}
+
+// Field is the name of the dense_vector field to search.
+func (q *KnnQuery) Field(field string) *KnnQuery {
+	q.field = field
+	return q
+}
+
+// QueryVector is the vector to find the nearest neighbors for. It must
+// have the same number of dimensions as the field.
+func (q *KnnQuery) QueryVector(queryVector []float64) *KnnQuery {
+	q.queryVector = queryVector
+	return q
+}
+
+// K is the number of nearest neighbors to return as top hits.
+func (q *KnnQuery) K(k int) *KnnQuery {
+	q.k = &k
+	return q
+}
+
+// NumCandidates is the number of nearest neighbor candidates to consider
+// per shard. It must be greater than or equal to K. Higher values
+// increase accuracy, but make the search slower.
+func (q *KnnQuery) NumCandidates(numCandidates int) *KnnQuery {
+	q.numCandidates = &numCandidates
+	return q
+}
+
+// Filter adds queries that the documents must match to be considered
+// as nearest neighbors.
+func (q *KnnQuery) Filter(filters ...Query) *KnnQuery {
+	q.filters = append(q.filters, filters...)
+	return q
+}
+
+// Boost sets the boost of the kNN scores when combining them with the
+// scores of the query in a hybrid search.
+func (q *KnnQuery) Boost(boost float64) *KnnQuery {
+	q.boost = &boost
+	return q
+}
+
+// Source returns the JSON-serializable data.
+func (q *KnnQuery) Source() (interface{}, error) {
+	// {
+	//   "field" : "image_vector",
+	//   "query_vector" : [0.3, 0.1, 1.2],
+	//   "k" : 10,
+	//   "num_candidates" : 100
+	// }
+	if q.field == "" {
+		return nil, errors.New("elastic: Field is required in KnnQuery")
+	}
+	if len(q.queryVector) == 0 {
+		return nil, errors.New("elastic: QueryVector is required in KnnQuery")
+	}
+	if q.k != nil && q.numCandidates != nil && *q.numCandidates < *q.k {
+		return nil, fmt.Errorf("elastic: NumCandidates (%d) must be greater than or equal to K (%d) in KnnQuery", *q.numCandidates, *q.k)
+	}
+
+	source := make(map[string]interface{})
+	source["field"] = q.field
+	source["query_vector"] = q.queryVector
+	if q.k != nil {
+		source["k"] = *q.k
+	}
+	if q.numCandidates != nil {
+		source["num_candidates"] = *q.numCandidates
+	}
+	switch len(q.filters) {
+	case 0:
+	case 1:
+		src, err := q.filters[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		source["filter"] = src
+	default:
+		var filters []interface{}
+		for _, f := range q.filters {
+			src, err := f.Source()
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, src)
+		}
+		source["filter"] = filters
+	}
+	if q.boost != nil {
+		source["boost"] = *q.boost
+	}
+	return source, nil
+}
@@ -0,0 +1,138 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKnnQuery(t *testing.T) {
+	q := NewKnnQuery("image_vector").
+		QueryVector([]float64{0.3, 0.1, 1.2}).
+		K(10).
+		NumCandidates(100).
+		Filter(NewTermQuery("file_type", "png"))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"field":"image_vector","filter":{"term":{"file_type":"png"}},"k":10,"num_candidates":100,"query_vector":[0.3,0.1,1.2]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestKnnQueryWithMultipleFiltersAndBoost(t *testing.T) {
+	q := NewKnnQuery("image_vector").
+		QueryVector([]float64{0.3, 0.1, 1.2}).
+		K(5).
+		Filter(NewTermQuery("file_type", "png"), NewRangeQuery("size").Lt(1024)).
+		Boost(0.5)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"boost":0.5,"field":"image_vector","filter":[{"term":{"file_type":"png"}},{"range":{"size":{"from":null,"include_lower":true,"include_upper":false,"to":1024}}}],"k":5,"query_vector":[0.3,0.1,1.2]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestKnnQueryValidate(t *testing.T) {
+	if _, err := NewKnnQuery("").QueryVector([]float64{1}).Source(); err == nil {
+		t.Error("expected error for missing field; got: nil")
+	}
+	if _, err := NewKnnQuery("image_vector").Source(); err == nil {
+		t.Error("expected error for missing query vector; got: nil")
+	}
+	if _, err := NewKnnQuery("image_vector").QueryVector([]float64{1}).K(10).NumCandidates(5).Source(); err == nil {
+		t.Error("expected error for num_candidates less than k; got: nil")
+	}
+}
+
+func TestSearchSourceKnn(t *testing.T) {
+	builder := NewSearchSource().
+		Knn(NewKnnQuery("vector").QueryVector([]float64{1, 2, 3}).K(10).NumCandidates(100)).
+		FetchSourceIncludeExclude([]string{"title"}, nil)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_source":{"includes":["title"]},"knn":{"field":"vector","k":10,"num_candidates":100,"query_vector":[1,2,3]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceKnnMultiple(t *testing.T) {
+	builder := NewSearchSource().
+		Query(NewMatchQuery("title", "mountain lake")).
+		Knn(
+			NewKnnQuery("image_vector").QueryVector([]float64{54, 10, -2}).K(5).NumCandidates(50),
+			NewKnnQuery("title_vector").QueryVector([]float64{1, 20, -52}).K(5).NumCandidates(50).Boost(0.2),
+		)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"knn":[{"field":"image_vector","k":5,"num_candidates":50,"query_vector":[54,10,-2]},{"boost":0.2,"field":"title_vector","k":5,"num_candidates":50,"query_vector":[1,20,-52]}],"query":{"match":{"title":{"query":"mountain lake"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceKnnSearch(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("expected to decode search body; got %v", err)
+		}
+		if want, have := `{"field":"vector","k":10,"num_candidates":100,"query_vector":[0.3,0.1,1.2]}`, string(body["knn"]); want != have {
+			t.Errorf("expected knn\n%s\n,got:\n%s", want, have)
+		}
+		fmt.Fprintln(w, `{"took":1,"hits":{"total":1,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1","_score":0.9}]}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	knn := NewKnnQuery("vector").QueryVector([]float64{0.3, 0.1, 1.2}).K(10).NumCandidates(100)
+	res, err := client.Search(testIndexName).KnnSearch(knn).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(1), res.TotalHits(); want != have {
+		t.Errorf("expected %d hit; got: %d", want, have)
+	}
+}
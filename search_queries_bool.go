@@ -18,6 +18,7 @@ type BoolQuery struct {
 	shouldClauses      []Query
 	boost              *float64
 	minimumShouldMatch string
+	minimumShouldSpec  *MinimumShouldMatchBuilder
 	adjustPureNegative *bool
 	queryName          string
 }
@@ -59,11 +60,21 @@ func (q *BoolQuery) Boost(boost float64) *BoolQuery {
 
 func (q *BoolQuery) MinimumShouldMatch(minimumShouldMatch string) *BoolQuery {
 	q.minimumShouldMatch = minimumShouldMatch
+	q.minimumShouldSpec = nil
 	return q
 }
 
 func (q *BoolQuery) MinimumNumberShouldMatch(minimumNumberShouldMatch int) *BoolQuery {
 	q.minimumShouldMatch = fmt.Sprintf("%d", minimumNumberShouldMatch)
+	q.minimumShouldSpec = nil
+	return q
+}
+
+// MinimumShouldMatchSpec sets minimum_should_match from a builder,
+// e.g. to use percentages or combinations like "3<90%".
+func (q *BoolQuery) MinimumShouldMatchSpec(spec *MinimumShouldMatchBuilder) *BoolQuery {
+	q.minimumShouldSpec = spec
+	q.minimumShouldMatch = ""
 	return q
 }
 
@@ -189,7 +200,13 @@ func (q *BoolQuery) Source() (interface{}, error) {
 	if q.boost != nil {
 		boolClause["boost"] = *q.boost
 	}
-	if q.minimumShouldMatch != "" {
+	if q.minimumShouldSpec != nil {
+		spec, err := q.minimumShouldSpec.Build()
+		if err != nil {
+			return nil, err
+		}
+		boolClause["minimum_should_match"] = spec
+	} else if q.minimumShouldMatch != "" {
 		boolClause["minimum_should_match"] = q.minimumShouldMatch
 	}
 	if q.adjustPureNegative != nil {
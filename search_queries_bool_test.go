@@ -31,3 +31,28 @@ func TestBoolQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestBoolQueryWithMinimumShouldMatchSpec(t *testing.T) {
+	q := NewBoolQuery().
+		Should(NewTermQuery("tag", "wow"), NewTermQuery("tag", "elasticsearch")).
+		MinimumShouldMatchSpec(NewMinimumShouldMatchBuilder().Combination(2, "-25%").Combination(9, "-3"))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"bool":{"minimum_should_match":"2\u003c-25% 9\u003c-3","should":[{"term":{"tag":"wow"}},{"term":{"tag":"elasticsearch"}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// Invalid specifications are reported by Source
+	q = NewBoolQuery().MinimumShouldMatchSpec(NewMinimumShouldMatchBuilder())
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error for invalid minimum_should_match specification")
+	}
+}
@@ -0,0 +1,145 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CombinedFieldsQuery searches multiple text fields as if their contents
+// had been indexed into one combined field. It is available as of
+// Elasticsearch 7.13.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.13/query-dsl-combined-fields-query.html
+type CombinedFieldsQuery struct {
+	text                            interface{}
+	fields                          []string
+	fieldBoosts                     map[string]*float64
+	operator                        string // AND or OR
+	minimumShouldMatch              string
+	zeroTermsQuery                  string
+	autoGenerateSynonymsPhraseQuery *bool
+	boost                           *float64
+	queryName                       string
+}
+
+// NewCombinedFieldsQuery creates and initializes a new CombinedFieldsQuery.
+func NewCombinedFieldsQuery(text interface{}, fields ...string) *CombinedFieldsQuery {
+	q := &CombinedFieldsQuery{
+		text:        text,
+		fields:      make([]string, 0),
+		fieldBoosts: make(map[string]*float64),
+	}
+	q.fields = append(q.fields, fields...)
+	return q
+}
+
+// Field adds a field to run the query against.
+func (q *CombinedFieldsQuery) Field(field string) *CombinedFieldsQuery {
+	q.fields = append(q.fields, field)
+	return q
+}
+
+// FieldWithBoost adds a field to run the query against with a specific boost.
+// The boost must be 1.0 or greater.
+func (q *CombinedFieldsQuery) FieldWithBoost(field string, boost float64) *CombinedFieldsQuery {
+	q.fields = append(q.fields, field)
+	q.fieldBoosts[field] = &boost
+	return q
+}
+
+// Operator sets the operator to use when combining the terms of the
+// query. It can be "and" or "or" (default).
+func (q *CombinedFieldsQuery) Operator(operator string) *CombinedFieldsQuery {
+	q.operator = operator
+	return q
+}
+
+// MinimumShouldMatch represents the minimum number of optional should clauses
+// to match.
+func (q *CombinedFieldsQuery) MinimumShouldMatch(minimumShouldMatch string) *CombinedFieldsQuery {
+	q.minimumShouldMatch = minimumShouldMatch
+	return q
+}
+
+// ZeroTermsQuery can be "all" or "none".
+func (q *CombinedFieldsQuery) ZeroTermsQuery(zeroTermsQuery string) *CombinedFieldsQuery {
+	q.zeroTermsQuery = zeroTermsQuery
+	return q
+}
+
+// AutoGenerateSynonymsPhraseQuery indicates whether phrase queries should be
+// automatically generated for multi terms synonyms. Defaults to true.
+func (q *CombinedFieldsQuery) AutoGenerateSynonymsPhraseQuery(enable bool) *CombinedFieldsQuery {
+	q.autoGenerateSynonymsPhraseQuery = &enable
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *CombinedFieldsQuery) Boost(boost float64) *CombinedFieldsQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched filters per hit.
+func (q *CombinedFieldsQuery) QueryName(queryName string) *CombinedFieldsQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *CombinedFieldsQuery) Source() (interface{}, error) {
+	//
+	// {
+	//   "combined_fields" : {
+	//     "query" : "database systems",
+	//     "fields" : [ "title", "abstract", "body" ],
+	//     "operator" : "and"
+	//   }
+	// }
+
+	source := make(map[string]interface{})
+
+	query := make(map[string]interface{})
+	source["combined_fields"] = query
+
+	query["query"] = q.text
+
+	if len(q.fields) > 0 {
+		var fields []string
+		for _, field := range q.fields {
+			if boost, found := q.fieldBoosts[field]; found && boost != nil {
+				fields = append(fields, fmt.Sprintf("%s^%f", field, *boost))
+			} else {
+				fields = append(fields, field)
+			}
+		}
+		query["fields"] = fields
+	}
+
+	if q.operator != "" {
+		query["operator"] = strings.ToLower(q.operator)
+	}
+	if q.minimumShouldMatch != "" {
+		query["minimum_should_match"] = q.minimumShouldMatch
+	}
+	if q.zeroTermsQuery != "" {
+		query["zero_terms_query"] = q.zeroTermsQuery
+	}
+	if q.autoGenerateSynonymsPhraseQuery != nil {
+		query["auto_generate_synonyms_phrase_query"] = *q.autoGenerateSynonymsPhraseQuery
+	}
+	if q.boost != nil {
+		query["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		query["_name"] = q.queryName
+	}
+
+	return source, nil
+}
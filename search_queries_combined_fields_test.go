@@ -0,0 +1,48 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCombinedFieldsQuery(t *testing.T) {
+	q := NewCombinedFieldsQuery("database systems", "title", "abstract", "body").Operator("and")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"combined_fields":{"fields":["title","abstract","body"],"operator":"and","query":"database systems"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestCombinedFieldsQueryWithOptions(t *testing.T) {
+	q := NewCombinedFieldsQuery("distributed consensus", "abstract").
+		FieldWithBoost("title", 2).
+		MinimumShouldMatch("3<80%").
+		ZeroTermsQuery("all").
+		AutoGenerateSynonymsPhraseQuery(false)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"combined_fields":{"auto_generate_synonyms_phrase_query":false,"fields":["abstract","title^2.000000"],"minimum_should_match":"3\u003c80%","query":"distributed consensus","zero_terms_query":"all"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
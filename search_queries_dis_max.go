@@ -4,6 +4,8 @@
 
 package elastic
 
+import "fmt"
+
 // DisMaxQuery is a query that generates the union of documents produced by
 // its subqueries, and that scores each document with the maximum score
 // for that document as produced by any subquery, plus a tie breaking
@@ -25,6 +27,12 @@ func NewDisMaxQuery() *DisMaxQuery {
 	}
 }
 
+// NewDisMaxQueryWith creates and initializes a new dis max query
+// with the given sub-queries.
+func NewDisMaxQueryWith(queries ...Query) *DisMaxQuery {
+	return NewDisMaxQuery().Query(queries...)
+}
+
 // Query adds one or more queries to the dis max query.
 func (q *DisMaxQuery) Query(queries ...Query) *DisMaxQuery {
 	q.queries = append(q.queries, queries...)
@@ -46,6 +54,7 @@ func (q *DisMaxQuery) Boost(boost float64) *DisMaxQuery {
 // that 10 occurrences of word in a lower-scored field that is also in a
 // higher scored field is just as good as a unique word in the lower scored
 // field (i.e., one that is not in any higher scored field).
+// It must be in the range [0,1]; Source returns an error otherwise.
 func (q *DisMaxQuery) TieBreaker(tieBreaker float64) *DisMaxQuery {
 	q.tieBreaker = &tieBreaker
 	return q
@@ -80,6 +89,9 @@ func (q *DisMaxQuery) Source() (interface{}, error) {
 	query["dis_max"] = params
 
 	if q.tieBreaker != nil {
+		if *q.tieBreaker < 0 || *q.tieBreaker > 1 {
+			return nil, fmt.Errorf("elastic: invalid tie_breaker %v in DisMaxQuery; must be in [0,1]", *q.tieBreaker)
+		}
 		params["tie_breaker"] = *q.tieBreaker
 	}
 	if q.boost != nil {
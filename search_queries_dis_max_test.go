@@ -26,3 +26,29 @@ func TestDisMaxQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestDisMaxQueryWith(t *testing.T) {
+	q := NewDisMaxQueryWith(NewTermQuery("age", 34), NewTermQuery("age", 35)).TieBreaker(1)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"dis_max":{"queries":[{"term":{"age":34}},{"term":{"age":35}}],"tie_breaker":1}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDisMaxQueryWithInvalidTieBreaker(t *testing.T) {
+	for _, tieBreaker := range []float64{-0.1, 1.5} {
+		q := NewDisMaxQueryWith(NewTermQuery("age", 34)).TieBreaker(tieBreaker)
+		if _, err := q.Source(); err == nil {
+			t.Errorf("expected error for tie_breaker %v", tieBreaker)
+		}
+	}
+}
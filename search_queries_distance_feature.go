@@ -0,0 +1,100 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// DistanceFeatureQuery boosts the relevance score of documents closer to a
+// provided origin date or point. It is typically used to rank recent or
+// nearby results higher, e.g. in the should clause of a bool query.
+// It is supported as of Elasticsearch 7.2.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.2/query-dsl-distance-feature-query.html
+type DistanceFeatureQuery struct {
+	field     string
+	origin    interface{}
+	pivot     string
+	boost     *float64
+	queryName string
+}
+
+// NewDistanceFeatureQuery creates and initializes a new DistanceFeatureQuery
+// for the given field. The field can either be a date, date_nanos, or
+// geo_point field.
+func NewDistanceFeatureQuery(field string) *DistanceFeatureQuery {
+	return &DistanceFeatureQuery{
+		field: field,
+	}
+}
+
+// Origin is the date or point of origin used to calculate distances.
+// For date fields, use a date string or date math expression like "now"
+// or "now-1h". For geo_point fields, use a *GeoPoint, a "lat,lon" string,
+// a geohash, or a [lon, lat] array.
+func (q *DistanceFeatureQuery) Origin(origin interface{}) *DistanceFeatureQuery {
+	q.origin = origin
+	return q
+}
+
+// Pivot is the distance from the origin at which relevance scores receive
+// half of the boost value. Use a time unit like "7d" for date fields and
+// a distance unit like "1km" for geo_point fields.
+func (q *DistanceFeatureQuery) Pivot(pivot string) *DistanceFeatureQuery {
+	q.pivot = pivot
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *DistanceFeatureQuery) Boost(boost float64) *DistanceFeatureQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched queries per hit.
+func (q *DistanceFeatureQuery) QueryName(queryName string) *DistanceFeatureQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns the JSON serializable content for this query.
+func (q *DistanceFeatureQuery) Source() (interface{}, error) {
+	// {
+	//   "distance_feature" : {
+	//     "field" : "production_date",
+	//     "origin" : "now",
+	//     "pivot" : "7d"
+	//   }
+	// }
+
+	if q.origin == nil {
+		return nil, errors.New("elastic: Origin is required in DistanceFeatureQuery")
+	}
+	if q.pivot == "" {
+		return nil, errors.New("elastic: Pivot is required in DistanceFeatureQuery")
+	}
+
+	query := make(map[string]interface{})
+	params := make(map[string]interface{})
+	query["distance_feature"] = params
+
+	params["field"] = q.field
+	switch origin := q.origin.(type) {
+	case *GeoPoint:
+		params["origin"] = origin.Source()
+	default:
+		params["origin"] = origin
+	}
+	params["pivot"] = q.pivot
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+
+	return query, nil
+}
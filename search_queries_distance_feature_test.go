@@ -0,0 +1,53 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDistanceFeatureQueryWithDateOrigin(t *testing.T) {
+	q := NewDistanceFeatureQuery("production_date").Origin("now").Pivot("7d")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"distance_feature":{"field":"production_date","origin":"now","pivot":"7d"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDistanceFeatureQueryWithGeoOrigin(t *testing.T) {
+	q := NewDistanceFeatureQuery("location").Origin(GeoPointFromLatLon(40, -70)).Pivot("1km").Boost(1.5)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"distance_feature":{"boost":1.5,"field":"location","origin":{"lat":40,"lon":-70},"pivot":"1km"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDistanceFeatureQueryRequiresOriginAndPivot(t *testing.T) {
+	if _, err := NewDistanceFeatureQuery("production_date").Pivot("7d").Source(); err == nil {
+		t.Error("expected error for missing origin; got: nil")
+	}
+	if _, err := NewDistanceFeatureQuery("production_date").Origin("now").Source(); err == nil {
+		t.Error("expected error for missing pivot; got: nil")
+	}
+}
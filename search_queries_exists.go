@@ -11,6 +11,7 @@ package elastic
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-exists-query.html
 type ExistsQuery struct {
 	name      string
+	boost     *float64
 	queryName string
 }
 
@@ -21,6 +22,20 @@ func NewExistsQuery(name string) *ExistsQuery {
 	}
 }
 
+// NewMissingQuery returns a query that matches documents that have no value
+// in the given field. It replaces the missing query that was removed in
+// Elasticsearch 5.0 and is a bool query with an exists query in its
+// must_not clause.
+func NewMissingQuery(name string) *BoolQuery {
+	return NewBoolQuery().MustNot(NewExistsQuery(name))
+}
+
+// Boost sets the boost for this query.
+func (q *ExistsQuery) Boost(boost float64) *ExistsQuery {
+	q.boost = &boost
+	return q
+}
+
 // QueryName sets the query name for the filter that can be used
 // when searching for matched queries per hit.
 func (q *ExistsQuery) QueryName(queryName string) *ExistsQuery {
@@ -41,6 +56,9 @@ func (q *ExistsQuery) Source() (interface{}, error) {
 	query["exists"] = params
 
 	params["field"] = q.name
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
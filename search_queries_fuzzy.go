@@ -45,21 +45,28 @@ func (q *FuzzyQuery) Fuzziness(fuzziness interface{}) *FuzzyQuery {
 	return q
 }
 
+// PrefixLength is the number of beginning characters left unchanged
+// when creating expansions (default: 0).
 func (q *FuzzyQuery) PrefixLength(prefixLength int) *FuzzyQuery {
 	q.prefixLength = &prefixLength
 	return q
 }
 
+// MaxExpansions is the maximum number of variations created (default: 50).
 func (q *FuzzyQuery) MaxExpansions(maxExpansions int) *FuzzyQuery {
 	q.maxExpansions = &maxExpansions
 	return q
 }
 
+// Transpositions indicates whether edits include transpositions of two
+// adjacent characters, e.g. ab → ba. Elasticsearch enables them by default.
 func (q *FuzzyQuery) Transpositions(transpositions bool) *FuzzyQuery {
 	q.transpositions = &transpositions
 	return q
 }
 
+// Rewrite specifies the method used to rewrite the query, e.g.
+// "constant_score" or "top_terms_boost_N".
 func (q *FuzzyQuery) Rewrite(rewrite string) *FuzzyQuery {
 	q.rewrite = rewrite
 	return q
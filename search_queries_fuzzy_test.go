@@ -25,3 +25,20 @@ func TestFuzzyQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestFuzzyQueryWithTranspositionsAndRewrite(t *testing.T) {
+	q := NewFuzzyQuery("user", "ki").Fuzziness("AUTO").Transpositions(false).Rewrite("constant_score")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fuzzy":{"user":{"fuzziness":"AUTO","rewrite":"constant_score","transpositions":false,"value":"ki"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -18,6 +18,7 @@ type GeoBoundingBoxQuery struct {
 	bottom    *float64
 	right     *float64
 	typ       string
+	boost     *float64
 	queryName string
 }
 
@@ -75,6 +76,12 @@ func (q *GeoBoundingBoxQuery) Type(typ string) *GeoBoundingBoxQuery {
 	return q
 }
 
+// Boost sets the boost for this query.
+func (q *GeoBoundingBoxQuery) Boost(boost float64) *GeoBoundingBoxQuery {
+	q.boost = &boost
+	return q
+}
+
 func (q *GeoBoundingBoxQuery) QueryName(queryName string) *GeoBoundingBoxQuery {
 	q.queryName = queryName
 	return q
@@ -113,6 +120,9 @@ func (q *GeoBoundingBoxQuery) Source() (interface{}, error) {
 	if q.typ != "" {
 		params["type"] = q.typ
 	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
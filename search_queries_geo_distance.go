@@ -10,13 +10,16 @@ package elastic
 // For more details, see:
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-geo-distance-query.html
 type GeoDistanceQuery struct {
-	name         string
-	distance     string
-	lat          float64
-	lon          float64
-	geohash      string
-	distanceType string
-	queryName    string
+	name             string
+	distance         string
+	lat              float64
+	lon              float64
+	geohash          string
+	distanceType     string
+	ignoreUnmapped   *bool
+	validationMethod string
+	boost            *float64
+	queryName        string
 }
 
 // NewGeoDistanceQuery creates and initializes a new GeoDistanceQuery.
@@ -24,6 +27,20 @@ func NewGeoDistanceQuery(name string) *GeoDistanceQuery {
 	return &GeoDistanceQuery{name: name}
 }
 
+// NewGeoDistanceRingQuery returns a query that matches documents whose
+// geo point in field name lies within maxDistance, but not within
+// minDistance, of point, i.e. in a ring around point. It replaces the
+// geo_distance_range query that was removed in Elasticsearch 6.0.
+//
+// The result is a bool query with a geo_distance query for maxDistance
+// in its must clause and a geo_distance query for minDistance in its
+// must_not clause. Documents exactly at minDistance are excluded.
+func NewGeoDistanceRingQuery(name string, point *GeoPoint, minDistance, maxDistance string) *BoolQuery {
+	outer := NewGeoDistanceQuery(name).GeoPoint(point).Distance(maxDistance)
+	inner := NewGeoDistanceQuery(name).GeoPoint(point).Distance(minDistance)
+	return NewBoolQuery().Must(outer).MustNot(inner)
+}
+
 func (q *GeoDistanceQuery) GeoPoint(point *GeoPoint) *GeoDistanceQuery {
 	q.lat = point.Lat
 	q.lon = point.Lon
@@ -61,6 +78,27 @@ func (q *GeoDistanceQuery) DistanceType(distanceType string) *GeoDistanceQuery {
 	return q
 }
 
+// IgnoreUnmapped indicates whether to ignore an unmapped field and not
+// match any documents for it instead of failing the query.
+func (q *GeoDistanceQuery) IgnoreUnmapped(ignoreUnmapped bool) *GeoDistanceQuery {
+	q.ignoreUnmapped = &ignoreUnmapped
+	return q
+}
+
+// ValidationMethod accepts IGNORE_MALFORMED, COERCE, and STRICT (default).
+// IGNORE_MALFORMED accepts geo points with invalid lat/lon.
+// COERCE tries to infer the correct lat/lon.
+func (q *GeoDistanceQuery) ValidationMethod(method string) *GeoDistanceQuery {
+	q.validationMethod = method
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *GeoDistanceQuery) Boost(boost float64) *GeoDistanceQuery {
+	q.boost = &boost
+	return q
+}
+
 func (q *GeoDistanceQuery) QueryName(queryName string) *GeoDistanceQuery {
 	q.queryName = queryName
 	return q
@@ -97,6 +135,15 @@ func (q *GeoDistanceQuery) Source() (interface{}, error) {
 	if q.distanceType != "" {
 		params["distance_type"] = q.distanceType
 	}
+	if q.ignoreUnmapped != nil {
+		params["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.validationMethod != "" {
+		params["validation_method"] = q.validationMethod
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
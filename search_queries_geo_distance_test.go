@@ -67,3 +67,41 @@ func TestGeoDistanceQueryWithGeoHash(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoDistanceQueryWithIgnoreUnmappedAndValidationMethod(t *testing.T) {
+	q := NewGeoDistanceQuery("pin.location")
+	q = q.Point(40, -70)
+	q = q.Distance("200km")
+	q = q.IgnoreUnmapped(true)
+	q = q.ValidationMethod("COERCE")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_distance":{"distance":"200km","ignore_unmapped":true,"pin.location":{"lat":40,"lon":-70},"validation_method":"COERCE"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoDistanceRingQuery(t *testing.T) {
+	q := NewGeoDistanceRingQuery("pin.location", GeoPointFromLatLon(40, -70), "100km", "200km")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"bool":{"must":{"geo_distance":{"distance":"200km","pin.location":{"lat":40,"lon":-70}}},"must_not":{"geo_distance":{"distance":"100km","pin.location":{"lat":40,"lon":-70}}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
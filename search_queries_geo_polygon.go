@@ -9,16 +9,18 @@ package elastic
 // For more details, see:
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-geo-polygon-query.html
 type GeoPolygonQuery struct {
-	name      string
-	points    []*GeoPoint
-	queryName string
+	name             string
+	points           []interface{}
+	validationMethod string
+	boost            *float64
+	queryName        string
 }
 
 // NewGeoPolygonQuery creates and initializes a new GeoPolygonQuery.
 func NewGeoPolygonQuery(name string) *GeoPolygonQuery {
 	return &GeoPolygonQuery{
 		name:   name,
-		points: make([]*GeoPoint, 0),
+		points: make([]interface{}, 0),
 	}
 }
 
@@ -34,6 +36,33 @@ func (q *GeoPolygonQuery) AddGeoPoint(point *GeoPoint) *GeoPolygonQuery {
 	return q
 }
 
+// AddGeoHash adds a point from a geohash, e.g. "drn5x1g8cu2y".
+func (q *GeoPolygonQuery) AddGeoHash(geohash string) *GeoPolygonQuery {
+	q.points = append(q.points, geohash)
+	return q
+}
+
+// AddPointString adds a point from a string in the form "lat,lon",
+// e.g. "40,-70".
+func (q *GeoPolygonQuery) AddPointString(latLon string) *GeoPolygonQuery {
+	q.points = append(q.points, latLon)
+	return q
+}
+
+// ValidationMethod accepts IGNORE_MALFORMED, COERCE, and STRICT (default).
+// IGNORE_MALFORMED accepts geo points with invalid lat/lon.
+// COERCE tries to infer the correct lat/lon.
+func (q *GeoPolygonQuery) ValidationMethod(method string) *GeoPolygonQuery {
+	q.validationMethod = method
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *GeoPolygonQuery) Boost(boost float64) *GeoPolygonQuery {
+	q.boost = &boost
+	return q
+}
+
 func (q *GeoPolygonQuery) QueryName(queryName string) *GeoPolygonQuery {
 	q.queryName = queryName
 	return q
@@ -60,10 +89,21 @@ func (q *GeoPolygonQuery) Source() (interface{}, error) {
 
 	var points []interface{}
 	for _, point := range q.points {
-		points = append(points, point.Source())
+		switch p := point.(type) {
+		case *GeoPoint:
+			points = append(points, p.Source())
+		default:
+			points = append(points, p)
+		}
 	}
 	polygon["points"] = points
 
+	if q.validationMethod != "" {
+		params["validation_method"] = q.validationMethod
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
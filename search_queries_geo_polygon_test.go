@@ -56,3 +56,25 @@ func TestGeoPolygonQueryFromGeoPoints(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoPolygonQueryFromGeoHashesWithValidationMethod(t *testing.T) {
+	q := NewGeoPolygonQuery("person.location")
+	q = q.AddGeoHash("drn5x1g8cu2y")
+	q = q.AddGeoHash("dr5r9ydj2y73")
+	q = q.AddPointString("20,-90")
+	q = q.AddPoint(30, -80)
+	q = q.ValidationMethod("IGNORE_MALFORMED")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_polygon":{"person.location":{"points":["drn5x1g8cu2y","dr5r9ydj2y73","20,-90",{"lat":30,"lon":-80}]},"validation_method":"IGNORE_MALFORMED"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
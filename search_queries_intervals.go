@@ -0,0 +1,348 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// IntervalsQuery returns documents based on the order and proximity
+// of matching terms. It is available as of Elasticsearch 7.0.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-intervals-query.html
+type IntervalsQuery struct {
+	field     string
+	rule      IntervalsRule
+	boost     *float64
+	queryName string
+}
+
+// NewIntervalsQuery creates and initializes a new IntervalsQuery
+// that matches the rule against the given field.
+func NewIntervalsQuery(field string, rule IntervalsRule) *IntervalsQuery {
+	return &IntervalsQuery{
+		field: field,
+		rule:  rule,
+	}
+}
+
+// NewIntervalsPhraseQuery creates and initializes a new IntervalsQuery
+// that matches the given terms in order, with at most maxGaps positions
+// in total between them. Each term is matched as a separate rule of an
+// ordered all_of rule. Use a negative maxGaps for no restriction.
+func NewIntervalsPhraseQuery(field string, maxGaps int, terms ...string) *IntervalsQuery {
+	rules := make([]IntervalsRule, 0, len(terms))
+	for _, term := range terms {
+		rules = append(rules, NewIntervalsMatch(term))
+	}
+	return NewIntervalsQuery(field, NewIntervalsAllOf(rules...).Ordered(true).MaxGaps(maxGaps))
+}
+
+// Boost sets the boost for this query.
+func (q *IntervalsQuery) Boost(boost float64) *IntervalsQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched_filters per hit.
+func (q *IntervalsQuery) QueryName(queryName string) *IntervalsQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *IntervalsQuery) Source() (interface{}, error) {
+	// {
+	//   "intervals" : {
+	//     "my_text" : {
+	//       "all_of" : { ... }
+	//     }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	intervals := make(map[string]interface{})
+	source["intervals"] = intervals
+
+	field := make(map[string]interface{})
+	intervals[q.field] = field
+
+	if q.rule != nil {
+		src, err := q.rule.Source()
+		if err != nil {
+			return nil, err
+		}
+		if m, ok := src.(map[string]interface{}); ok {
+			for k, v := range m {
+				field[k] = v
+			}
+		}
+	}
+	if q.boost != nil {
+		field["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		field["_name"] = q.queryName
+	}
+
+	return source, nil
+}
+
+// -- Rules --
+
+// IntervalsRule is a rule of an IntervalsQuery, e.g. IntervalsMatch
+// or IntervalsAllOf.
+type IntervalsRule interface {
+	// Source returns the JSON-serializable rule, e.g. {"match":{...}}.
+	Source() (interface{}, error)
+}
+
+// intervalsRulesSource returns the JSON-serializable list of rules.
+func intervalsRulesSource(rules []IntervalsRule) ([]interface{}, error) {
+	list := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		src, err := rule.Source()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, src)
+	}
+	return list, nil
+}
+
+// -- match --
+
+// IntervalsMatch matches analyzed text.
+type IntervalsMatch struct {
+	query    string
+	maxGaps  *int
+	ordered  *bool
+	analyzer string
+	useField string
+}
+
+// NewIntervalsMatch creates and initializes a new IntervalsMatch rule.
+func NewIntervalsMatch(query string) *IntervalsMatch {
+	return &IntervalsMatch{query: query}
+}
+
+// MaxGaps specifies the maximum number of positions between the
+// matching terms. Terms further apart are not considered matches.
+// The default is -1, i.e. no restriction.
+func (r *IntervalsMatch) MaxGaps(maxGaps int) *IntervalsMatch {
+	r.maxGaps = &maxGaps
+	return r
+}
+
+// Ordered indicates whether the matching terms must appear in the
+// order specified. Default is false.
+func (r *IntervalsMatch) Ordered(ordered bool) *IntervalsMatch {
+	r.ordered = &ordered
+	return r
+}
+
+// Analyzer specifies the analyzer used to analyze the query.
+func (r *IntervalsMatch) Analyzer(analyzer string) *IntervalsMatch {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals from the given field instead of the
+// field of the query.
+func (r *IntervalsMatch) UseField(useField string) *IntervalsMatch {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsMatch) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	rule := make(map[string]interface{})
+	source["match"] = rule
+
+	rule["query"] = r.query
+	if r.maxGaps != nil {
+		rule["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		rule["ordered"] = *r.ordered
+	}
+	if r.analyzer != "" {
+		rule["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		rule["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- prefix --
+
+// IntervalsPrefix matches terms that start with a specified set of characters.
+type IntervalsPrefix struct {
+	prefix   string
+	analyzer string
+	useField string
+}
+
+// NewIntervalsPrefix creates and initializes a new IntervalsPrefix rule.
+func NewIntervalsPrefix(prefix string) *IntervalsPrefix {
+	return &IntervalsPrefix{prefix: prefix}
+}
+
+// Analyzer specifies the analyzer used to normalize the prefix.
+func (r *IntervalsPrefix) Analyzer(analyzer string) *IntervalsPrefix {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals from the given field instead of the
+// field of the query.
+func (r *IntervalsPrefix) UseField(useField string) *IntervalsPrefix {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsPrefix) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	rule := make(map[string]interface{})
+	source["prefix"] = rule
+
+	rule["prefix"] = r.prefix
+	if r.analyzer != "" {
+		rule["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		rule["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- wildcard --
+
+// IntervalsWildcard matches terms using a wildcard pattern.
+type IntervalsWildcard struct {
+	pattern  string
+	analyzer string
+	useField string
+}
+
+// NewIntervalsWildcard creates and initializes a new IntervalsWildcard rule.
+func NewIntervalsWildcard(pattern string) *IntervalsWildcard {
+	return &IntervalsWildcard{pattern: pattern}
+}
+
+// Analyzer specifies the analyzer used to normalize the pattern.
+func (r *IntervalsWildcard) Analyzer(analyzer string) *IntervalsWildcard {
+	r.analyzer = analyzer
+	return r
+}
+
+// UseField matches intervals from the given field instead of the
+// field of the query.
+func (r *IntervalsWildcard) UseField(useField string) *IntervalsWildcard {
+	r.useField = useField
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsWildcard) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	rule := make(map[string]interface{})
+	source["wildcard"] = rule
+
+	rule["pattern"] = r.pattern
+	if r.analyzer != "" {
+		rule["analyzer"] = r.analyzer
+	}
+	if r.useField != "" {
+		rule["use_field"] = r.useField
+	}
+	return source, nil
+}
+
+// -- all_of --
+
+// IntervalsAllOf returns matches that span a combination of other rules.
+type IntervalsAllOf struct {
+	intervals []IntervalsRule
+	maxGaps   *int
+	ordered   *bool
+}
+
+// NewIntervalsAllOf creates and initializes a new IntervalsAllOf rule.
+func NewIntervalsAllOf(intervals ...IntervalsRule) *IntervalsAllOf {
+	return &IntervalsAllOf{intervals: intervals}
+}
+
+// Intervals adds rules to combine.
+func (r *IntervalsAllOf) Intervals(intervals ...IntervalsRule) *IntervalsAllOf {
+	r.intervals = append(r.intervals, intervals...)
+	return r
+}
+
+// MaxGaps specifies the maximum number of positions between the
+// matches of the rules. The default is -1, i.e. no restriction.
+func (r *IntervalsAllOf) MaxGaps(maxGaps int) *IntervalsAllOf {
+	r.maxGaps = &maxGaps
+	return r
+}
+
+// Ordered indicates whether the matches of the rules must appear
+// in the order specified. Default is false.
+func (r *IntervalsAllOf) Ordered(ordered bool) *IntervalsAllOf {
+	r.ordered = &ordered
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsAllOf) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	rule := make(map[string]interface{})
+	source["all_of"] = rule
+
+	intervals, err := intervalsRulesSource(r.intervals)
+	if err != nil {
+		return nil, err
+	}
+	rule["intervals"] = intervals
+	if r.maxGaps != nil {
+		rule["max_gaps"] = *r.maxGaps
+	}
+	if r.ordered != nil {
+		rule["ordered"] = *r.ordered
+	}
+	return source, nil
+}
+
+// -- any_of --
+
+// IntervalsAnyOf returns intervals produced by any of its rules.
+type IntervalsAnyOf struct {
+	intervals []IntervalsRule
+}
+
+// NewIntervalsAnyOf creates and initializes a new IntervalsAnyOf rule.
+func NewIntervalsAnyOf(intervals ...IntervalsRule) *IntervalsAnyOf {
+	return &IntervalsAnyOf{intervals: intervals}
+}
+
+// Intervals adds rules to choose from.
+func (r *IntervalsAnyOf) Intervals(intervals ...IntervalsRule) *IntervalsAnyOf {
+	r.intervals = append(r.intervals, intervals...)
+	return r
+}
+
+// Source returns JSON for the rule.
+func (r *IntervalsAnyOf) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	rule := make(map[string]interface{})
+	source["any_of"] = rule
+
+	intervals, err := intervalsRulesSource(r.intervals)
+	if err != nil {
+		return nil, err
+	}
+	rule["intervals"] = intervals
+	return source, nil
+}
@@ -0,0 +1,90 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestIntervalsQuery(t *testing.T) {
+	q := NewIntervalsQuery("my_text",
+		NewIntervalsAllOf(
+			NewIntervalsMatch("my favorite food").MaxGaps(0).Ordered(true),
+			NewIntervalsAnyOf(
+				NewIntervalsMatch("hot water"),
+				NewIntervalsMatch("cold porridge"),
+			),
+		).Ordered(true),
+	)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"intervals":{"my_text":{"all_of":{"intervals":[{"match":{"max_gaps":0,"ordered":true,"query":"my favorite food"}},{"any_of":{"intervals":[{"match":{"query":"hot water"}},{"match":{"query":"cold porridge"}}]}}],"ordered":true}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestIntervalsQueryRules(t *testing.T) {
+	tests := []struct {
+		Query    *IntervalsQuery
+		Expected string
+	}{
+		{
+			NewIntervalsQuery("my_text", NewIntervalsMatch("hot porridge").MaxGaps(10).Analyzer("standard").UseField("my_text.stemmed")),
+			`{"intervals":{"my_text":{"match":{"analyzer":"standard","max_gaps":10,"query":"hot porridge","use_field":"my_text.stemmed"}}}}`,
+		},
+		{
+			NewIntervalsQuery("my_text", NewIntervalsPrefix("out")),
+			`{"intervals":{"my_text":{"prefix":{"prefix":"out"}}}}`,
+		},
+		{
+			NewIntervalsQuery("my_text", NewIntervalsWildcard("out?ide").Analyzer("keyword")),
+			`{"intervals":{"my_text":{"wildcard":{"analyzer":"keyword","pattern":"out?ide"}}}}`,
+		},
+		{
+			NewIntervalsQuery("my_text", NewIntervalsAllOf(NewIntervalsMatch("hot"), NewIntervalsPrefix("porr")).MaxGaps(2)).Boost(1.5).QueryName("food"),
+			`{"intervals":{"my_text":{"_name":"food","all_of":{"intervals":[{"match":{"query":"hot"}},{"prefix":{"prefix":"porr"}}],"max_gaps":2},"boost":1.5}}}`,
+		},
+	}
+
+	for i, test := range tests {
+		src, err := test.Query.Source()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("case #%d: marshaling to JSON failed: %v", i+1, err)
+		}
+		if got := string(data); got != test.Expected {
+			t.Errorf("case #%d: expected\n%s\n,got:\n%s", i+1, test.Expected, got)
+		}
+	}
+}
+
+func TestIntervalsPhraseQuery(t *testing.T) {
+	q := NewIntervalsPhraseQuery("my_text", 2, "quick", "brown", "fox")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"intervals":{"my_text":{"all_of":{"intervals":[{"match":{"query":"quick"}},{"match":{"query":"brown"}},{"match":{"query":"fox"}}],"max_gaps":2,"ordered":true}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// MatchBoolPrefixQuery analyzes its input and constructs a bool query
+// from the terms. Each term except the last is used in a term query,
+// the last term is used in a prefix query. It is useful for
+// search-as-you-type and is supported as of Elasticsearch 7.2.
+//
+// For more details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.2/query-dsl-match-bool-prefix-query.html
+type MatchBoolPrefixQuery struct {
+	name                string
+	queryText           interface{}
+	analyzer            string
+	operator            string
+	minimumShouldMatch  string
+	fuzziness           string
+	prefixLength        *int
+	maxExpansions       *int
+	fuzzyTranspositions *bool
+	fuzzyRewrite        string
+	boost               *float64
+	queryName           string
+}
+
+// NewMatchBoolPrefixQuery creates and initializes a new MatchBoolPrefixQuery.
+func NewMatchBoolPrefixQuery(name string, queryText interface{}) *MatchBoolPrefixQuery {
+	return &MatchBoolPrefixQuery{name: name, queryText: queryText}
+}
+
+// Query sets the text to analyze and search for.
+func (q *MatchBoolPrefixQuery) Query(queryText interface{}) *MatchBoolPrefixQuery {
+	q.queryText = queryText
+	return q
+}
+
+// Analyzer explicitly sets the analyzer to use. It defaults to use explicit
+// mapping config for the field, or, if not set, the default search analyzer.
+func (q *MatchBoolPrefixQuery) Analyzer(analyzer string) *MatchBoolPrefixQuery {
+	q.analyzer = analyzer
+	return q
+}
+
+// Operator sets the operator to use to combine the terms.
+// Can be "AND" or "OR" (default).
+func (q *MatchBoolPrefixQuery) Operator(operator string) *MatchBoolPrefixQuery {
+	q.operator = operator
+	return q
+}
+
+// MinimumShouldMatch sets the optional minimumShouldMatch value to
+// apply to the resulting bool query.
+func (q *MatchBoolPrefixQuery) MinimumShouldMatch(minimumShouldMatch string) *MatchBoolPrefixQuery {
+	q.minimumShouldMatch = minimumShouldMatch
+	return q
+}
+
+// Fuzziness sets the fuzziness of the term queries, e.g. "AUTO".
+// It is not applied to the prefix query on the last term.
+func (q *MatchBoolPrefixQuery) Fuzziness(fuzziness string) *MatchBoolPrefixQuery {
+	q.fuzziness = fuzziness
+	return q
+}
+
+// PrefixLength sets the length of a length of common (non-fuzzy)
+// prefix for fuzzy term queries. It must be non-negative.
+func (q *MatchBoolPrefixQuery) PrefixLength(prefixLength int) *MatchBoolPrefixQuery {
+	q.prefixLength = &prefixLength
+	return q
+}
+
+// MaxExpansions specifies the number of term expansions to use
+// for fuzzy term queries.
+func (q *MatchBoolPrefixQuery) MaxExpansions(maxExpansions int) *MatchBoolPrefixQuery {
+	q.maxExpansions = &maxExpansions
+	return q
+}
+
+// FuzzyTranspositions sets whether transpositions are supported in
+// fuzzy term queries.
+func (q *MatchBoolPrefixQuery) FuzzyTranspositions(fuzzyTranspositions bool) *MatchBoolPrefixQuery {
+	q.fuzzyTranspositions = &fuzzyTranspositions
+	return q
+}
+
+// FuzzyRewrite sets the fuzzy_rewrite parameter controlling how the
+// fuzzy term queries will get rewritten.
+func (q *MatchBoolPrefixQuery) FuzzyRewrite(fuzzyRewrite string) *MatchBoolPrefixQuery {
+	q.fuzzyRewrite = fuzzyRewrite
+	return q
+}
+
+// Boost sets the boost to apply to this query.
+func (q *MatchBoolPrefixQuery) Boost(boost float64) *MatchBoolPrefixQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched filters per hit.
+func (q *MatchBoolPrefixQuery) QueryName(queryName string) *MatchBoolPrefixQuery {
+	q.queryName = queryName
+	return q
+}
+
+// Source returns JSON for the query.
+func (q *MatchBoolPrefixQuery) Source() (interface{}, error) {
+	// {"match_bool_prefix":{"message":{"query":"quick brown f","analyzer":"keyword"}}}
+	source := make(map[string]interface{})
+
+	match := make(map[string]interface{})
+	source["match_bool_prefix"] = match
+
+	if q.isShortForm() {
+		// Use the short form: {"match_bool_prefix":{"message":"quick brown f"}}
+		match[q.name] = q.queryText
+		return source, nil
+	}
+
+	query := make(map[string]interface{})
+	match[q.name] = query
+
+	query["query"] = q.queryText
+
+	if q.analyzer != "" {
+		query["analyzer"] = q.analyzer
+	}
+	if q.operator != "" {
+		query["operator"] = q.operator
+	}
+	if q.minimumShouldMatch != "" {
+		query["minimum_should_match"] = q.minimumShouldMatch
+	}
+	if q.fuzziness != "" {
+		query["fuzziness"] = q.fuzziness
+	}
+	if q.prefixLength != nil {
+		query["prefix_length"] = *q.prefixLength
+	}
+	if q.maxExpansions != nil {
+		query["max_expansions"] = *q.maxExpansions
+	}
+	if q.fuzzyTranspositions != nil {
+		query["fuzzy_transpositions"] = *q.fuzzyTranspositions
+	}
+	if q.fuzzyRewrite != "" {
+		query["fuzzy_rewrite"] = q.fuzzyRewrite
+	}
+	if q.boost != nil {
+		query["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		query["_name"] = q.queryName
+	}
+
+	return source, nil
+}
+
+// isShortForm returns true if no options besides the query text are set.
+func (q *MatchBoolPrefixQuery) isShortForm() bool {
+	return q.analyzer == "" &&
+		q.operator == "" &&
+		q.minimumShouldMatch == "" &&
+		q.fuzziness == "" &&
+		q.prefixLength == nil &&
+		q.maxExpansions == nil &&
+		q.fuzzyTranspositions == nil &&
+		q.fuzzyRewrite == "" &&
+		q.boost == nil &&
+		q.queryName == ""
+}
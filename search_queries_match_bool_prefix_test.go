@@ -0,0 +1,50 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMatchBoolPrefixQuery(t *testing.T) {
+	q := NewMatchBoolPrefixQuery("message", "quick brown f")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"match_bool_prefix":{"message":"quick brown f"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMatchBoolPrefixQueryWithOptions(t *testing.T) {
+	q := NewMatchBoolPrefixQuery("message", "quikc brown f").
+		Analyzer("standard").
+		Operator("and").
+		MinimumShouldMatch("2").
+		Fuzziness("AUTO").
+		PrefixLength(1).
+		MaxExpansions(10)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"match_bool_prefix":{"message":{"analyzer":"standard","fuzziness":"AUTO","max_expansions":10,"minimum_should_match":"2","operator":"and","prefix_length":1,"query":"quikc brown f"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
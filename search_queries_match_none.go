@@ -10,6 +10,7 @@ package elastic
 // For more details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-match-all-query.html
 type MatchNoneQuery struct {
+	boost     *float64
 	queryName string
 }
 
@@ -18,6 +19,12 @@ func NewMatchNoneQuery() *MatchNoneQuery {
 	return &MatchNoneQuery{}
 }
 
+// Boost sets the boost for this query.
+func (q *MatchNoneQuery) Boost(boost float64) *MatchNoneQuery {
+	q.boost = &boost
+	return q
+}
+
 // QueryName sets the query name.
 func (q *MatchNoneQuery) QueryName(name string) *MatchNoneQuery {
 	q.queryName = name
@@ -32,6 +39,9 @@ func (q MatchNoneQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
 	params := make(map[string]interface{})
 	source["match_none"] = params
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
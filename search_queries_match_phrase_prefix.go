@@ -10,13 +10,14 @@ package elastic
 // For more details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-match-query-phrase-prefix.html
 type MatchPhrasePrefixQuery struct {
-	name          string
-	value         interface{}
-	analyzer      string
-	slop          *int
-	maxExpansions *int
-	boost         *float64
-	queryName     string
+	name           string
+	value          interface{}
+	analyzer       string
+	slop           *int
+	maxExpansions  *int
+	zeroTermsQuery string
+	boost          *float64
+	queryName      string
 }
 
 // NewMatchPhrasePrefixQuery creates and initializes a new MatchPhrasePrefixQuery.
@@ -43,6 +44,13 @@ func (q *MatchPhrasePrefixQuery) MaxExpansions(n int) *MatchPhrasePrefixQuery {
 	return q
 }
 
+// ZeroTermsQuery can be "all" or "none". It specifies what to return
+// if the analyzer removes all tokens, e.g. because they are all stop words.
+func (q *MatchPhrasePrefixQuery) ZeroTermsQuery(zeroTermsQuery string) *MatchPhrasePrefixQuery {
+	q.zeroTermsQuery = zeroTermsQuery
+	return q
+}
+
 // Boost sets the boost to apply to this query.
 func (q *MatchPhrasePrefixQuery) Boost(boost float64) *MatchPhrasePrefixQuery {
 	q.boost = &boost
@@ -64,6 +72,12 @@ func (q *MatchPhrasePrefixQuery) Source() (interface{}, error) {
 	match := make(map[string]interface{})
 	source["match_phrase_prefix"] = match
 
+	if q.isShortForm() {
+		// Use the short form: {"match_phrase_prefix":{"name":"value"}}
+		match[q.name] = q.value
+		return source, nil
+	}
+
 	query := make(map[string]interface{})
 	match[q.name] = query
 
@@ -78,6 +92,9 @@ func (q *MatchPhrasePrefixQuery) Source() (interface{}, error) {
 	if q.maxExpansions != nil {
 		query["max_expansions"] = *q.maxExpansions
 	}
+	if q.zeroTermsQuery != "" {
+		query["zero_terms_query"] = q.zeroTermsQuery
+	}
 	if q.boost != nil {
 		query["boost"] = *q.boost
 	}
@@ -87,3 +104,13 @@ func (q *MatchPhrasePrefixQuery) Source() (interface{}, error) {
 
 	return source, nil
 }
+
+// isShortForm returns true if no options besides the query text are set.
+func (q *MatchPhrasePrefixQuery) isShortForm() bool {
+	return q.analyzer == "" &&
+		q.slop == nil &&
+		q.maxExpansions == nil &&
+		q.zeroTermsQuery == "" &&
+		q.boost == nil &&
+		q.queryName == ""
+}
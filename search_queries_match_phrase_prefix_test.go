@@ -25,3 +25,41 @@ func TestMatchPhrasePrefixQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestMatchPhrasePrefixQueryShortForm(t *testing.T) {
+	q := NewMatchPhrasePrefixQuery("message", "quick brown f")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"match_phrase_prefix":{"message":"quick brown f"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMatchPhrasePrefixQueryWithOptions(t *testing.T) {
+	q := NewMatchPhrasePrefixQuery("message", "quick brown f").
+		Slop(2).
+		MaxExpansions(10).
+		Analyzer("standard").
+		ZeroTermsQuery("all")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"match_phrase_prefix":{"message":{"analyzer":"standard","max_expansions":10,"query":"quick brown f","slop":2,"zero_terms_query":"all"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
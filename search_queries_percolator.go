@@ -21,6 +21,8 @@ type PercolatorQuery struct {
 	indexedDocumentRouting    string
 	indexedDocumentPreference string
 	indexedDocumentVersion    *int64
+	boost                     *float64
+	queryName                 string
 }
 
 // NewPercolatorQuery creates and initializes a new Percolator query.
@@ -81,6 +83,19 @@ func (q *PercolatorQuery) IndexedDocumentVersion(version int64) *PercolatorQuery
 	return q
 }
 
+// Boost sets the boost for this query.
+func (q *PercolatorQuery) Boost(boost float64) *PercolatorQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched_filters per hit.
+func (q *PercolatorQuery) QueryName(queryName string) *PercolatorQuery {
+	q.queryName = queryName
+	return q
+}
+
 // Source returns JSON for the percolate query.
 func (q *PercolatorQuery) Source() (interface{}, error) {
 	if len(q.field) == 0 {
@@ -127,5 +142,11 @@ func (q *PercolatorQuery) Source() (interface{}, error) {
 	if v := q.indexedDocumentVersion; v != nil {
 		params["version"] = *v
 	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
 	return source, nil
 }
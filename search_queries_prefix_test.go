@@ -43,3 +43,20 @@ func TestPrefixQueryWithOptions(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestPrefixQueryWithCaseInsensitive(t *testing.T) {
+	q := NewPrefixQuery("user", "ki").CaseInsensitive(true)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"prefix":{"user":{"case_insensitive":true,"value":"ki"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
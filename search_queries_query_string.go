@@ -29,6 +29,7 @@ type QueryStringQuery struct {
 	fuzzyPrefixLength        *int
 	fuzzyMaxExpansions       *int
 	fuzzyRewrite             string
+	fuzzyTranspositions      *bool
 	phraseSlop               *int
 	fields                   []string
 	fieldBoosts              map[string]*float64
@@ -173,6 +174,13 @@ func (q *QueryStringQuery) FuzzyRewrite(fuzzyRewrite string) *QueryStringQuery {
 	return q
 }
 
+// FuzzyTranspositions indicates whether transpositions (ab → ba) are
+// allowed in fuzzy queries. Default is true.
+func (q *QueryStringQuery) FuzzyTranspositions(fuzzyTranspositions bool) *QueryStringQuery {
+	q.fuzzyTranspositions = &fuzzyTranspositions
+	return q
+}
+
 // PhraseSlop sets the default slop for phrases. If zero, then exact matches
 // are required. Default value is zero.
 func (q *QueryStringQuery) PhraseSlop(phraseSlop int) *QueryStringQuery {
@@ -312,6 +320,9 @@ func (q *QueryStringQuery) Source() (interface{}, error) {
 	if q.fuzzyRewrite != "" {
 		query["fuzzy_rewrite"] = q.fuzzyRewrite
 	}
+	if q.fuzzyTranspositions != nil {
+		query["fuzzy_transpositions"] = *q.fuzzyTranspositions
+	}
 	if q.phraseSlop != nil {
 		query["phrase_slop"] = *q.phraseSlop
 	}
@@ -46,6 +46,52 @@ func TestQueryStringQueryTimeZone(t *testing.T) {
 	}
 }
 
+func TestQueryStringQueryOptions(t *testing.T) {
+	tests := []struct {
+		Query    *QueryStringQuery
+		Expected string
+	}{
+		{
+			NewQueryStringQuery("golang").Type("best_fields"),
+			`{"query_string":{"query":"golang","type":"best_fields"}}`,
+		},
+		{
+			NewQueryStringQuery(`"golang elasticsearch"`).PhraseSlop(2),
+			`{"query_string":{"phrase_slop":2,"query":"\"golang elasticsearch\""}}`,
+		},
+		{
+			NewQueryStringQuery("gol*").AnalyzeWildcard(true),
+			`{"query_string":{"analyze_wildcard":true,"query":"gol*"}}`,
+		},
+		{
+			NewQueryStringQuery("*lang").AllowLeadingWildcard(false),
+			`{"query_string":{"allow_leading_wildcard":false,"query":"*lang"}}`,
+		},
+		{
+			NewQueryStringQuery("golnag~").FuzzyTranspositions(false),
+			`{"query_string":{"fuzzy_transpositions":false,"query":"golnag~"}}`,
+		},
+		{
+			NewQueryStringQuery("created:[2018-01-01 TO now]").TimeZone("+01:00"),
+			`{"query_string":{"query":"created:[2018-01-01 TO now]","time_zone":"+01:00"}}`,
+		},
+	}
+
+	for i, test := range tests {
+		src, err := test.Query.Source()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("case #%d: marshaling to JSON failed: %v", i+1, err)
+		}
+		if got := string(data); got != test.Expected {
+			t.Errorf("case #%d: expected\n%s\n,got:\n%s", i+1, test.Expected, got)
+		}
+	}
+}
+
 func TestQueryStringQueryIntegration(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", log.LstdFlags)))
 
@@ -70,3 +116,28 @@ func TestQueryStringQueryIntegration(t *testing.T) {
 		t.Errorf("expected len(SearchResult.Hits.Hits) = %d; got %d", want, got)
 	}
 }
+
+func TestQueryStringQueryAnalyzeWildcardIntegration(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", log.LstdFlags)))
+
+	// The wildcard term is analyzed, i.e. lowercased, before matching
+	q := NewQueryStringQuery("GOLAN*").DefaultField("message").AnalyzeWildcard(true)
+
+	searchResult, err := client.Search().
+		Index(testIndexName).
+		Query(q).
+		Pretty(true).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searchResult.Hits == nil {
+		t.Errorf("expected SearchResult.Hits != nil; got nil")
+	}
+	if got, want := searchResult.Hits.TotalHits, int64(1); got != want {
+		t.Errorf("expected SearchResult.Hits.TotalHits = %d; got %d", want, got)
+	}
+	if got, want := len(searchResult.Hits.Hits), 1; got != want {
+		t.Errorf("expected len(SearchResult.Hits.Hits) = %d; got %d", want, got)
+	}
+}
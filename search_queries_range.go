@@ -4,8 +4,25 @@
 
 package elastic
 
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// rangeQueryTimeFormat is the format set by RangeQuery if one of
+	// its bounds is a time.Time and no explicit format is given.
+	rangeQueryTimeFormat = "strict_date_optional_time"
+)
+
 // RangeQuery matches documents with fields that have terms within a certain range.
 //
+// Bounds may be passed as time.Time (or *time.Time). They are serialized
+// in RFC 3339 format, preserving their time zone offset, and the format
+// of the query is set to "strict_date_optional_time" unless Format is
+// used. A zero time.Time is treated as an unbounded part.
+//
 // For details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-range-query.html
 type RangeQuery struct {
@@ -113,8 +130,9 @@ func (q *RangeQuery) Format(format string) *RangeQuery {
 	return q
 }
 
-// Relation is used for range fields. which can be one of
-// "within", "contains", "intersects" (default) and "disjoint".
+// Relation is used for range fields, e.g. integer_range or date_range.
+// It can be one of "INTERSECTS" (default), "CONTAINS", and "WITHIN"
+// (case-insensitive). Other values are rejected by Source.
 func (q *RangeQuery) Relation(relation string) *RangeQuery {
 	q.relation = relation
 	return q
@@ -130,15 +148,24 @@ func (q *RangeQuery) Source() (interface{}, error) {
 	params := make(map[string]interface{})
 	rangeQ[q.name] = params
 
-	params["from"] = q.from
-	params["to"] = q.to
+	from, fromIsTime := rangeQueryBound(q.from)
+	to, toIsTime := rangeQueryBound(q.to)
+	params["from"] = from
+	params["to"] = to
 	if q.timeZone != "" {
 		params["time_zone"] = q.timeZone
 	}
 	if q.format != "" {
 		params["format"] = q.format
+	} else if fromIsTime || toIsTime {
+		params["format"] = rangeQueryTimeFormat
 	}
 	if q.relation != "" {
+		switch strings.ToUpper(q.relation) {
+		case "INTERSECTS", "CONTAINS", "WITHIN":
+		default:
+			return nil, fmt.Errorf("elastic: invalid relation %q in RangeQuery; must be one of INTERSECTS, CONTAINS, or WITHIN", q.relation)
+		}
 		params["relation"] = q.relation
 	}
 	if q.boost != nil {
@@ -153,3 +180,22 @@ func (q *RangeQuery) Source() (interface{}, error) {
 
 	return source, nil
 }
+
+// rangeQueryBound returns the serialized form of a bound of a RangeQuery.
+// It formats non-zero times as RFC 3339 and returns true for them;
+// zero times are returned as nil, i.e. unbounded.
+func rangeQueryBound(v interface{}) (interface{}, bool) {
+	switch t := v.(type) {
+	case time.Time:
+		if t.IsZero() {
+			return nil, false
+		}
+		return t.Format(time.RFC3339Nano), true
+	case *time.Time:
+		if t == nil || t.IsZero() {
+			return nil, false
+		}
+		return t.Format(time.RFC3339Nano), true
+	}
+	return v, false
+}
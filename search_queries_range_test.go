@@ -7,6 +7,7 @@ package elastic
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestRangeQuery(t *testing.T) {
@@ -27,6 +28,30 @@ func TestRangeQuery(t *testing.T) {
 	}
 }
 
+func TestRangeQueryWithRelationOnDateRangeField(t *testing.T) {
+	q := NewRangeQuery("event_period").Gte("2019-01-01").Lte("2019-12-31").Format("yyyy-MM-dd").Relation("WITHIN")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"event_period":{"format":"yyyy-MM-dd","from":"2019-01-01","include_lower":true,"include_upper":true,"relation":"WITHIN","to":"2019-12-31"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRangeQueryWithInvalidRelation(t *testing.T) {
+	q := NewRangeQuery("event_period").Gte("2019-01-01").Relation("DISJOINT")
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error for invalid relation; got: nil")
+	}
+}
+
 func TestRangeQueryWithTimeZone(t *testing.T) {
 	q := NewRangeQuery("born").
 		Gte("2012-01-01").
@@ -66,3 +91,75 @@ func TestRangeQueryWithFormat(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestRangeQueryWithTime(t *testing.T) {
+	loc := time.FixedZone("CEST", 2*60*60)
+	from := time.Date(2020, 5, 1, 8, 30, 0, 0, loc)
+	to := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	q := NewRangeQuery("postDate").Gte(from).Lt(to)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"postDate":{"format":"strict_date_optional_time","from":"2020-05-01T08:30:00+02:00","include_lower":true,"include_upper":false,"to":"2020-06-01T00:00:00Z"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRangeQueryWithTimeAndFormat(t *testing.T) {
+	from := time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC)
+	q := NewRangeQuery("postDate").Gte(&from).Format("date_time_no_millis")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"postDate":{"format":"date_time_no_millis","from":"2020-05-01T00:00:00Z","include_lower":true,"include_upper":true,"to":null}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRangeQueryWithZeroTime(t *testing.T) {
+	to := time.Date(2020, 6, 1, 0, 0, 0, 0, time.UTC)
+	q := NewRangeQuery("postDate").Gt(time.Time{}).Lte(to)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"range":{"postDate":{"format":"strict_date_optional_time","from":null,"include_lower":false,"include_upper":true,"to":"2020-06-01T00:00:00Z"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// No format if both bounds are unset
+	q = NewRangeQuery("postDate").Gte(time.Time{})
+	src, err = q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got = string(data)
+	expected = `{"range":{"postDate":{"from":null,"include_lower":true,"include_upper":true,"to":null}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
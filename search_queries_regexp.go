@@ -16,6 +16,7 @@ type RegexpQuery struct {
 	rewrite               string
 	queryName             string
 	maxDeterminizedStates *int
+	caseInsensitive       *bool
 }
 
 // NewRegexpQuery creates and initializes a new RegexpQuery.
@@ -23,18 +24,29 @@ func NewRegexpQuery(name string, regexp string) *RegexpQuery {
 	return &RegexpQuery{name: name, regexp: regexp}
 }
 
-// Flags sets the regexp flags.
+// Flags sets the regexp flags, e.g. "INTERSECTION|COMPLEMENT|EMPTY".
+// Valid flags are ALL (default), ANYSTRING, COMPLEMENT, EMPTY, INTERSECTION,
+// INTERVAL, and NONE, separated by a pipe.
 func (q *RegexpQuery) Flags(flags string) *RegexpQuery {
 	q.flags = flags
 	return q
 }
 
 // MaxDeterminizedStates protects against complex regular expressions.
+// It limits the number of automaton states the regular expression may
+// require (default: 10000).
 func (q *RegexpQuery) MaxDeterminizedStates(maxDeterminizedStates int) *RegexpQuery {
 	q.maxDeterminizedStates = &maxDeterminizedStates
 	return q
 }
 
+// CaseInsensitive, if true, allows case-insensitive matching of the value
+// with the indexed field values. It is supported as of Elasticsearch 7.10.
+func (q *RegexpQuery) CaseInsensitive(caseInsensitive bool) *RegexpQuery {
+	q.caseInsensitive = &caseInsensitive
+	return q
+}
+
 // Boost sets the boost for this query.
 func (q *RegexpQuery) Boost(boost float64) *RegexpQuery {
 	q.boost = &boost
@@ -67,6 +79,9 @@ func (q *RegexpQuery) Source() (interface{}, error) {
 	if q.maxDeterminizedStates != nil {
 		x["max_determinized_states"] = *q.maxDeterminizedStates
 	}
+	if q.caseInsensitive != nil {
+		x["case_insensitive"] = *q.caseInsensitive
+	}
 	if q.boost != nil {
 		x["boost"] = *q.boost
 	}
@@ -74,7 +89,7 @@ func (q *RegexpQuery) Source() (interface{}, error) {
 		x["rewrite"] = q.rewrite
 	}
 	if q.queryName != "" {
-		x["name"] = q.queryName
+		x["_name"] = q.queryName
 	}
 	query[q.name] = x
 
@@ -40,7 +40,43 @@ func TestRegexpQueryWithOptions(t *testing.T) {
 		t.Fatalf("marshaling to JSON failed: %v", err)
 	}
 	got := string(data)
-	expected := `{"regexp":{"name.first":{"boost":1.2,"flags":"INTERSECTION|COMPLEMENT|EMPTY","name":"my_query_name","value":"s.*y"}}}`
+	expected := `{"regexp":{"name.first":{"_name":"my_query_name","boost":1.2,"flags":"INTERSECTION|COMPLEMENT|EMPTY","value":"s.*y"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRegexpQueryWithMaxDeterminizedStates(t *testing.T) {
+	q := NewRegexpQuery("name.first", "s.*y").
+		Flags("INTERSECTION|COMPLEMENT").
+		MaxDeterminizedStates(20000)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"regexp":{"name.first":{"flags":"INTERSECTION|COMPLEMENT","max_determinized_states":20000,"value":"s.*y"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestRegexpQueryWithCaseInsensitive(t *testing.T) {
+	q := NewRegexpQuery("name.first", "s.*y").CaseInsensitive(true)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"regexp":{"name.first":{"case_insensitive":true,"value":"s.*y"}}}`
 	if got != expected {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
@@ -12,6 +12,7 @@ import "errors"
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-script-query.html
 type ScriptQuery struct {
 	script    *Script
+	boost     *float64
 	queryName string
 }
 
@@ -22,6 +23,12 @@ func NewScriptQuery(script *Script) *ScriptQuery {
 	}
 }
 
+// Boost sets the boost for this query.
+func (q *ScriptQuery) Boost(boost float64) *ScriptQuery {
+	q.boost = &boost
+	return q
+}
+
 // QueryName sets the query name for the filter that can be used
 // when searching for matched_filters per hit
 func (q *ScriptQuery) QueryName(queryName string) *ScriptQuery {
@@ -44,6 +51,9 @@ func (q *ScriptQuery) Source() (interface{}, error) {
 	}
 	params["script"] = src
 
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
 	if q.queryName != "" {
 		params["_name"] = q.queryName
 	}
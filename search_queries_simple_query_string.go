@@ -54,7 +54,7 @@ func (q *SimpleQueryStringQuery) Field(field string) *SimpleQueryStringQuery {
 	return q
 }
 
-// Field adds a field to run the query against with a specific boost.
+// FieldWithBoost adds a field to run the query against with a specific boost.
 func (q *SimpleQueryStringQuery) FieldWithBoost(field string, boost float64) *SimpleQueryStringQuery {
 	q.fields = append(q.fields, field)
 	q.fieldBoosts[field] = &boost
@@ -93,7 +93,10 @@ func (q *SimpleQueryStringQuery) DefaultOperator(defaultOperator string) *Simple
 	return q
 }
 
-// Flags sets the flags for the query.
+// Flags sets the flags for the query, i.e. the operators the parser
+// supports, separated by "|", e.g. "AND|OR|PREFIX". Valid flags include
+// ALL (default), NONE, AND, OR, NOT, PREFIX, PHRASE, PRECEDENCE, ESCAPE,
+// WHITESPACE, FUZZY, NEAR, and SLOP.
 func (q *SimpleQueryStringQuery) Flags(flags string) *SimpleQueryStringQuery {
 	q.flags = flags
 	return q
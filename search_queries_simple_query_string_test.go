@@ -27,6 +27,67 @@ func TestSimpleQueryStringQuery(t *testing.T) {
 	}
 }
 
+func TestSimpleQueryStringQueryWithOptions(t *testing.T) {
+	q := NewSimpleQueryStringQuery(`"fried eggs" +(eggplant | potato) -frittata`).
+		FieldWithBoost("title", 5).
+		Field("body").
+		Flags("OR|AND|PREFIX").
+		QuoteFieldSuffix(".exact").
+		FuzzyPrefixLength(1).
+		FuzzyMaxExpansions(10).
+		FuzzyTranspositions(false)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"simple_query_string":{"fields":["title^5.000000","body"],"flags":"OR|AND|PREFIX","fuzzy_max_expansions":10,"fuzzy_prefix_length":1,"fuzzy_transpositions":false,"query":"\"fried eggs\" +(eggplant | potato) -frittata","quote_field_suffix":".exact"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSimpleQueryStringQueryWithFlags(t *testing.T) {
+	q := NewSimpleQueryStringQuery(`foo bar -baz*`).Field("content").Flags("OR|AND|PREFIX")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"simple_query_string":{"fields":["content"],"flags":"OR|AND|PREFIX","query":"foo bar -baz*"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSimpleQueryStringQueryWithWeightedFields(t *testing.T) {
+	q := NewSimpleQueryStringQuery(`fried eggs`).
+		FieldWithBoost("title", 3).
+		FieldWithBoost("summary", 1.5).
+		Field("body")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"simple_query_string":{"fields":["title^3.000000","summary^1.500000","body"],"query":"fried eggs"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSimpleQueryStringQueryExec(t *testing.T) {
 	// client := setupTestClientAndCreateIndexAndLog(t, SetTraceLog(log.New(os.Stdout, "", 0)))
 	client := setupTestClientAndCreateIndex(t)
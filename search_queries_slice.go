@@ -4,8 +4,12 @@
 
 package elastic
 
+import "fmt"
+
 // SliceQuery allows to partition the documents into several slices.
 // It is used e.g. to slice scroll operations in Elasticsearch 5.0 or later.
+// To scroll in parallel, run one scroll per slice with the same Max and
+// a distinct Id between 0 and Max-1.
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-scroll.html#sliced-scroll
 // for details.
 type SliceQuery struct {
@@ -25,13 +29,14 @@ func (s *SliceQuery) Field(field string) *SliceQuery {
 	return s
 }
 
-// Id is the id of the slice.
+// Id is the id of the slice. It must be less than Max.
 func (s *SliceQuery) Id(id int) *SliceQuery {
 	s.id = &id
 	return s
 }
 
-// Max is the maximum number of slices.
+// Max is the maximum number of slices. It must be the same for all
+// slices of a sliced operation.
 func (s *SliceQuery) Max(max int) *SliceQuery {
 	s.max = &max
 	return s
@@ -39,6 +44,15 @@ func (s *SliceQuery) Max(max int) *SliceQuery {
 
 // Source returns the JSON body.
 func (s *SliceQuery) Source() (interface{}, error) {
+	if s.max != nil && *s.max <= 1 {
+		return nil, fmt.Errorf("elastic: invalid max %d in SliceQuery; must be greater than 1", *s.max)
+	}
+	if s.id != nil && *s.id < 0 {
+		return nil, fmt.Errorf("elastic: invalid id %d in SliceQuery; must be greater than or equal to 0", *s.id)
+	}
+	if s.id != nil && s.max != nil && *s.id >= *s.max {
+		return nil, fmt.Errorf("elastic: invalid id %d in SliceQuery; must be less than max %d", *s.id, *s.max)
+	}
 	m := make(map[string]interface{})
 	if s.field != "" {
 		m["field"] = s.field
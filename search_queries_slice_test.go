@@ -25,3 +25,45 @@ func TestSliceQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestSliceQueryValidate(t *testing.T) {
+	tests := []struct {
+		Query     *SliceQuery
+		ExpectErr bool
+	}{
+		{NewSliceQuery().Id(0).Max(4), false},
+		{NewSliceQuery().Id(3).Max(4), false},
+		{NewSliceQuery().Id(4).Max(4), true},
+		{NewSliceQuery().Id(-1).Max(4), true},
+		{NewSliceQuery().Id(0).Max(1), true},
+	}
+	for i, tt := range tests {
+		_, err := tt.Query.Source()
+		if tt.ExpectErr && err == nil {
+			t.Errorf("case #%d: expected error", i+1)
+		}
+		if !tt.ExpectErr && err != nil {
+			t.Errorf("case #%d: expected no error; got: %v", i+1, err)
+		}
+	}
+}
+
+func TestSearchSourceWithSlice(t *testing.T) {
+	src, err := NewSearchSource().Slice(NewSliceQuery().Id(0).Max(4)).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"slice":{"id":0,"max":4}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	if _, err := NewSearchSource().Slice(NewSliceQuery().Id(4).Max(4)).Source(); err == nil {
+		t.Fatal("expected error for invalid slice")
+	}
+}
@@ -10,10 +10,11 @@ package elastic
 // For details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-term-query.html
 type TermQuery struct {
-	name      string
-	value     interface{}
-	boost     *float64
-	queryName string
+	name            string
+	value           interface{}
+	boost           *float64
+	caseInsensitive *bool
+	queryName       string
 }
 
 // NewTermQuery creates and initializes a new TermQuery.
@@ -27,6 +28,13 @@ func (q *TermQuery) Boost(boost float64) *TermQuery {
 	return q
 }
 
+// CaseInsensitive, if true, allows case-insensitive matching of the value
+// with the indexed field values. It is supported as of Elasticsearch 7.10.
+func (q *TermQuery) CaseInsensitive(caseInsensitive bool) *TermQuery {
+	q.caseInsensitive = &caseInsensitive
+	return q
+}
+
 // QueryName sets the query name for the filter that can be used
 // when searching for matched_filters per hit
 func (q *TermQuery) QueryName(queryName string) *TermQuery {
@@ -41,7 +49,7 @@ func (q *TermQuery) Source() (interface{}, error) {
 	tq := make(map[string]interface{})
 	source["term"] = tq
 
-	if q.boost == nil && q.queryName == "" {
+	if q.boost == nil && q.queryName == "" && q.caseInsensitive == nil {
 		tq[q.name] = q.value
 	} else {
 		subQ := make(map[string]interface{})
@@ -49,6 +57,9 @@ func (q *TermQuery) Source() (interface{}, error) {
 		if q.boost != nil {
 			subQ["boost"] = *q.boost
 		}
+		if q.caseInsensitive != nil {
+			subQ["case_insensitive"] = *q.caseInsensitive
+		}
 		if q.queryName != "" {
 			subQ["_name"] = q.queryName
 		}
@@ -29,6 +29,12 @@ func NewTermsQuery(name string, values ...interface{}) *TermsQuery {
 	return q
 }
 
+// NewTermsQueryFromLookup creates and initializes a new TermsQuery
+// that fetches the terms from the field of another document.
+func NewTermsQueryFromLookup(name string, lookup *TermsLookup) *TermsQuery {
+	return NewTermsQuery(name).TermsLookup(lookup)
+}
+
 // TermsLookup adds terms lookup details to the query.
 func (q *TermsQuery) TermsLookup(lookup *TermsLookup) *TermsQuery {
 	q.termsLookup = lookup
@@ -62,6 +62,24 @@ func TestTermsQueryWithTermsLookup(t *testing.T) {
 	}
 }
 
+func TestTermsQueryFromLookup(t *testing.T) {
+	q := NewTermsQueryFromLookup("user",
+		NewTermsLookup().Index("users").Id("2").Path("followers").Routing("tenant-1"))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"terms":{"user":{"id":"2","index":"users","path":"followers","routing":"tenant-1"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestTermQuerysWithOptions(t *testing.T) {
 	q := NewTermsQuery("user", "ki", "ko")
 	q = q.Boost(2.79)
@@ -0,0 +1,68 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestLeafQueriesWithBoostAndQueryName(t *testing.T) {
+	tests := []struct {
+		Name  string
+		Query Query
+	}{
+		{"common", NewCommonTermsQuery("message", "Golang").Boost(1.5).QueryName("tagged")},
+		{"combined_fields", NewCombinedFieldsQuery("Golang", "message").Boost(1.5).QueryName("tagged")},
+		{"exists", NewExistsQuery("user").Boost(1.5).QueryName("tagged")},
+		{"fuzzy", NewFuzzyQuery("user", "olivere").Boost(1.5).QueryName("tagged")},
+		{"geo_bounding_box", NewGeoBoundingBoxQuery("location").TopLeft(40.73, -74.1).BottomRight(40.01, -71.12).Boost(1.5).QueryName("tagged")},
+		{"geo_distance", NewGeoDistanceQuery("location").Point(40, -70).Distance("200km").Boost(1.5).QueryName("tagged")},
+		{"geo_polygon", NewGeoPolygonQuery("location").AddPoint(40, -70).AddPoint(30, -80).AddPoint(20, -90).Boost(1.5).QueryName("tagged")},
+		{"ids", NewIdsQuery("doc").Ids("1").Boost(1.5).QueryName("tagged")},
+		{"intervals", NewIntervalsQuery("message", NewIntervalsMatch("Golang")).Boost(1.5).QueryName("tagged")},
+		{"match", NewMatchQuery("message", "Golang").Boost(1.5).QueryName("tagged")},
+		{"match_all", NewMatchAllQuery().Boost(1.5).QueryName("tagged")},
+		{"match_none", NewMatchNoneQuery().Boost(1.5).QueryName("tagged")},
+		{"match_phrase", NewMatchPhraseQuery("message", "Golang").Boost(1.5).QueryName("tagged")},
+		{"match_phrase_prefix", NewMatchPhrasePrefixQuery("message", "Gol").Boost(1.5).QueryName("tagged")},
+		{"more_like_this", NewMoreLikeThisQuery().Field("message").LikeText("Golang").Boost(1.5).QueryName("tagged")},
+		{"multi_match", NewMultiMatchQuery("Golang", "message").Boost(1.5).QueryName("tagged")},
+		{"parent_id", NewParentIdQuery("comment", "1").Boost(1.5).QueryName("tagged")},
+		{"percolate", NewPercolatorQuery().Field("query").Document(map[string]interface{}{"message": "Golang"}).Boost(1.5).QueryName("tagged")},
+		{"prefix", NewPrefixQuery("user", "oli").Boost(1.5).QueryName("tagged")},
+		{"query_string", NewQueryStringQuery("Golang").Boost(1.5).QueryName("tagged")},
+		{"range", NewRangeQuery("retweets").Gte(10).Boost(1.5).QueryName("tagged")},
+		{"regexp", NewRegexpQuery("user", "oli.*").Boost(1.5).QueryName("tagged")},
+		{"script", NewScriptQuery(NewScript("doc['retweets'].value > 1")).Boost(1.5).QueryName("tagged")},
+		{"simple_query_string", NewSimpleQueryStringQuery("Golang").Boost(1.5).QueryName("tagged")},
+		{"term", NewTermQuery("user", "olivere").Boost(1.5).QueryName("tagged")},
+		{"terms", NewTermsQuery("user", "olivere", "sandrae").Boost(1.5).QueryName("tagged")},
+		{"terms_set", NewTermsSetQuery("tags", "a", "b").MinimumShouldMatchField("required").Boost(1.5).QueryName("tagged")},
+		{"type", NewTypeQuery("doc").Boost(1.5).QueryName("tagged")},
+		{"wildcard", NewWildcardQuery("user", "oli*").Boost(1.5).QueryName("tagged")},
+	}
+
+	for _, test := range tests {
+		src, err := test.Query.Source()
+		if err != nil {
+			t.Errorf("%s: %v", test.Name, err)
+			continue
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Errorf("%s: marshaling to JSON failed: %v", test.Name, err)
+			continue
+		}
+		got := string(data)
+		if !strings.Contains(got, `"boost":1.5`) {
+			t.Errorf("%s: expected boost in\n%s", test.Name, got)
+		}
+		if !strings.Contains(got, `"_name":"tagged"`) {
+			t.Errorf("%s: expected _name in\n%s", test.Name, got)
+		}
+	}
+}
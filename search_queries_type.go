@@ -9,18 +9,39 @@ package elastic
 // For details, see:
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-type-query.html
 type TypeQuery struct {
-	typ string
+	typ       string
+	boost     *float64
+	queryName string
 }
 
 func NewTypeQuery(typ string) *TypeQuery {
 	return &TypeQuery{typ: typ}
 }
 
+// Boost sets the boost for this query.
+func (q *TypeQuery) Boost(boost float64) *TypeQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched_filters per hit.
+func (q *TypeQuery) QueryName(queryName string) *TypeQuery {
+	q.queryName = queryName
+	return q
+}
+
 // Source returns JSON for the query.
 func (q *TypeQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
 	params := make(map[string]interface{})
 	source["type"] = params
 	params["value"] = q.typ
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
 	return source, nil
 }
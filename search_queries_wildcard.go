@@ -15,11 +15,12 @@ package elastic
 // For more details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.2/query-dsl-wildcard-query.html
 type WildcardQuery struct {
-	name      string
-	wildcard  string
-	boost     *float64
-	rewrite   string
-	queryName string
+	name            string
+	wildcard        string
+	boost           *float64
+	rewrite         string
+	caseInsensitive *bool
+	queryName       string
 }
 
 // NewWildcardQuery creates and initializes a new WildcardQuery.
@@ -41,6 +42,13 @@ func (q *WildcardQuery) Rewrite(rewrite string) *WildcardQuery {
 	return q
 }
 
+// CaseInsensitive, if true, allows case-insensitive matching of the value
+// with the indexed field values. It is supported as of Elasticsearch 7.10.
+func (q *WildcardQuery) CaseInsensitive(caseInsensitive bool) *WildcardQuery {
+	q.caseInsensitive = &caseInsensitive
+	return q
+}
+
 // QueryName sets the name of this query.
 func (q *WildcardQuery) QueryName(queryName string) *WildcardQuery {
 	q.queryName = queryName
@@ -73,6 +81,9 @@ func (q *WildcardQuery) Source() (interface{}, error) {
 	if q.rewrite != "" {
 		wq["rewrite"] = q.rewrite
 	}
+	if q.caseInsensitive != nil {
+		wq["case_insensitive"] = *q.caseInsensitive
+	}
 	if q.queryName != "" {
 		wq["_name"] = q.queryName
 	}
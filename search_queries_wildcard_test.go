@@ -66,3 +66,20 @@ func TestWildcardQueryWithBoost(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestWildcardQueryWithCaseInsensitive(t *testing.T) {
+	q := elastic.NewWildcardQuery("user", "ki*y").CaseInsensitive(true)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"wildcard":{"user":{"case_insensitive":true,"wildcard":"ki*y"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -4,25 +4,77 @@
 
 package elastic
 
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
 // WrapperQuery accepts any other query as base64 encoded string.
 //
 // For details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/6.3/query-dsl-wrapper-query.html.
 type WrapperQuery struct {
-	source string
+	source interface{}
 }
 
 // NewWrapperQuery creates and initializes a new WrapperQuery.
-func NewWrapperQuery(source string) *WrapperQuery {
+//
+// The source can be one of the following:
+// A string with either the base64 encoded query or the raw query JSON,
+// a []byte or json.RawMessage with the raw query JSON,
+// a Query, or any other value that serializes to the query JSON.
+// Everything but an already encoded string is serialized and
+// base64 encoded when calling Source.
+func NewWrapperQuery(source interface{}) *WrapperQuery {
 	return &WrapperQuery{source: source}
 }
 
 // Source returns JSON for the query.
 func (q *WrapperQuery) Source() (interface{}, error) {
 	// {"wrapper":{"query":"..."}}
+	query, err := q.encodedQuery()
+	if err != nil {
+		return nil, err
+	}
 	source := make(map[string]interface{})
 	tq := make(map[string]interface{})
 	source["wrapper"] = tq
-	tq["query"] = q.source
+	tq["query"] = query
 	return source, nil
 }
+
+// encodedQuery returns the base64 encoded query.
+func (q *WrapperQuery) encodedQuery() (string, error) {
+	var data []byte
+	switch v := q.source.(type) {
+	case string:
+		// The base64 alphabet does not contain "{", so a string
+		// starting with it must be the raw query JSON.
+		if !strings.HasPrefix(strings.TrimSpace(v), "{") {
+			return v, nil
+		}
+		data = []byte(v)
+	case []byte:
+		data = v
+	case json.RawMessage:
+		data = v
+	case Query:
+		src, err := v.Source()
+		if err != nil {
+			return "", err
+		}
+		data, err = json.Marshal(src)
+		if err != nil {
+			return "", fmt.Errorf("elastic: unable to serialize wrapped query: %v", err)
+		}
+	default:
+		var err error
+		data, err = json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("elastic: unable to serialize wrapped query: %v", err)
+		}
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
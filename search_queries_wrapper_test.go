@@ -25,3 +25,32 @@ func TestWrapperQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestWrapperQueryEncodesSource(t *testing.T) {
+	// {"term":{"user":"Kimchy"}}
+	expected := `{"wrapper":{"query":"eyJ0ZXJtIjp7InVzZXIiOiJLaW1jaHkifX0="}}`
+
+	tests := []struct {
+		Source interface{}
+	}{
+		{`{"term":{"user":"Kimchy"}}`},
+		{[]byte(`{"term":{"user":"Kimchy"}}`)},
+		{json.RawMessage(`{"term":{"user":"Kimchy"}}`)},
+		{NewTermQuery("user", "Kimchy")},
+		{map[string]interface{}{"term": map[string]interface{}{"user": "Kimchy"}}},
+	}
+
+	for i, test := range tests {
+		src, err := NewWrapperQuery(test.Source).Source()
+		if err != nil {
+			t.Fatalf("case #%d: %v", i+1, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("case #%d: marshaling to JSON failed: %v", i+1, err)
+		}
+		if got := string(data); got != expected {
+			t.Errorf("case #%d: expected\n%s\n,got:\n%s", i+1, expected, got)
+		}
+	}
+}
@@ -40,6 +40,9 @@ type SearchSource struct {
 	innerHits                map[string]*InnerHit
 	collapse                 *CollapseBuilder
 	profile                  bool
+	pointInTime              *PointInTime
+	runtimeMappings          map[string]interface{}
+	knn                      []*KnnQuery
 	// TODO extBuilders []SearchExtBuilder
 }
 
@@ -354,6 +357,39 @@ func (s *SearchSource) Collapse(collapse *CollapseBuilder) *SearchSource {
 	return s
 }
 
+// PointInTime specifies the point in time to search, see PointInTimeService.
+func (s *SearchSource) PointInTime(pointInTime *PointInTime) *SearchSource {
+	s.pointInTime = pointInTime
+	return s
+}
+
+// RuntimeMappings defines runtime fields for this search request only.
+// The runtime fields can be used like regular fields, e.g. in queries,
+// sorts, and aggregations. It is supported as of Elasticsearch 7.11.
+//
+// Example:
+//
+//	NewSearchSource().RuntimeMappings(map[string]interface{}{
+//		"day_of_week": map[string]interface{}{
+//			"type":   "keyword",
+//			"script": "emit(doc['timestamp'].value.dayOfWeekEnum.toString())",
+//		},
+//	})
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.11/runtime-search-request.html
+// for details.
+func (s *SearchSource) RuntimeMappings(runtimeMappings map[string]interface{}) *SearchSource {
+	s.runtimeMappings = runtimeMappings
+	return s
+}
+
+// Knn adds one or more k-nearest neighbor searches on dense_vector fields.
+// Multiple kNN searches require Elasticsearch 8.7 or later.
+func (s *SearchSource) Knn(knn ...*KnnQuery) *SearchSource {
+	s.knn = append(s.knn, knn...)
+	return s
+}
+
 // Source returns the serializable JSON for the source builder.
 func (s *SearchSource) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -532,6 +568,38 @@ func (s *SearchSource) Source() (interface{}, error) {
 		source["collapse"] = src
 	}
 
+	if s.pointInTime != nil {
+		src, err := s.pointInTime.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["pit"] = src
+	}
+
+	if len(s.runtimeMappings) > 0 {
+		source["runtime_mappings"] = s.runtimeMappings
+	}
+
+	switch len(s.knn) {
+	case 0:
+	case 1:
+		src, err := s.knn[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		source["knn"] = src
+	default:
+		var knn []interface{}
+		for _, q := range s.knn {
+			src, err := q.Source()
+			if err != nil {
+				return nil, err
+			}
+			knn = append(knn, src)
+		}
+		source["knn"] = knn
+	}
+
 	if len(s.innerHits) > 0 {
 		// Top-level inner hits
 		// See http://www.elastic.co/guide/en/elasticsearch/reference/1.5/search-request-inner-hits.html#top-level-inner-hits
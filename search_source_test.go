@@ -27,6 +27,39 @@ func TestSearchSourceMatchAllQuery(t *testing.T) {
 	}
 }
 
+func TestSearchSourceMinScoreAndTrackScores(t *testing.T) {
+	mlt := NewMoreLikeThisQuery().Field("message").LikeText("Golang topic")
+	builder := NewSearchSource().Query(mlt).MinScore(0.5).TrackScores(true)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"min_score":0.5,"query":{"more_like_this":{"fields":["message"],"like":["Golang topic"]}},"track_scores":true}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// min_score is omitted when unset
+	src, err = NewSearchSource().Query(mlt).TrackScores(true).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got = string(data)
+	expected = `{"query":{"more_like_this":{"fields":["message"],"like":["Golang topic"]}},"track_scores":true}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceNoStoredFields(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	builder := NewSearchSource().Query(matchAllQ).NoStoredFields()
@@ -298,3 +331,50 @@ func TestSearchSourceProfiledQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestSearchSourcePointInTime(t *testing.T) {
+	matchAllQ := NewMatchAllQuery()
+	builder := NewSearchSource().Query(matchAllQ).
+		PointInTime(NewPointInTime("pit-1", "1m")).
+		SearchAfter(1463538857, "tweet#654323")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"pit":{"id":"pit-1","keep_alive":"1m"},"query":{"match_all":{}},"search_after":[1463538857,"tweet#654323"]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceRuntimeMappings(t *testing.T) {
+	builder := NewSearchSource().
+		Query(NewTermQuery("day_of_week", "MONDAY")).
+		FetchSource(false).
+		RuntimeMappings(map[string]interface{}{
+			"day_of_week": map[string]interface{}{
+				"type":   "keyword",
+				"script": "emit(doc['timestamp'].value.dayOfWeekEnum.toString())",
+			},
+		}).
+		SortBy(NewFieldSort("day_of_week").Desc()).
+		Aggregation("days", NewTermsAggregation().Field("day_of_week"))
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_source":false,"aggregations":{"days":{"terms":{"field":"day_of_week"}}},"query":{"term":{"day_of_week":"MONDAY"}},"runtime_mappings":{"day_of_week":{"script":"emit(doc['timestamp'].value.dayOfWeekEnum.toString())","type":"keyword"}},"sort":[{"day_of_week":{"order":"desc"}}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
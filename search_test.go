@@ -7,7 +7,11 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -1087,6 +1091,105 @@ func TestSearchBuildURL(t *testing.T) {
 	}
 }
 
+func TestSearchRouting(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Routing  []string
+		Expected string
+	}{
+		{
+			[]string{"user1"},
+			"user1",
+		},
+		{
+			[]string{"user1", "user2"},
+			"user1,user2",
+		},
+	}
+
+	for i, test := range tests {
+		_, params, err := client.Search(testIndexName).Routing(test.Routing...).buildURL()
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if want, have := test.Expected, params.Get("routing"); want != have {
+			t.Errorf("case #%d: expected routing = %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestSearchMinCompatibleShardNode(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.Search(testIndexName).MinCompatibleShardNode("7.10.0").buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "7.10.0", params.Get("min_compatible_shard_node"); want != have {
+		t.Fatalf("expected min_compatible_shard_node = %q; got: %q", want, have)
+	}
+
+	_, params, err = client.Search(testIndexName).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := params["min_compatible_shard_node"]; found {
+		t.Fatalf("expected no min_compatible_shard_node; got: %q", params.Get("min_compatible_shard_node"))
+	}
+}
+
+func TestSearchPreference(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		Preference string
+		Valid      bool
+	}{
+		{"my-session-id", true},
+		{PreferenceLocal, true},
+		{PreferenceOnlyLocal, true},
+		{PreferencePrimary, true},
+		{PreferenceReplica, true},
+		{PreferenceShardsPrefix + "2,3", true},
+		{PreferenceShardsPrefix + "2,3|" + PreferenceLocal, true},
+		{PreferenceShardsPrefix + "1|my-session-id", true},
+		{PreferenceOnlyNodesPrefix + "node1,node2", true},
+		{PreferenceShardsPrefix, false},
+		{PreferenceShardsPrefix + "a,b", false},
+		{PreferenceShardsPrefix + "1,", false},
+		{PreferenceShardsPrefix + "-1", false},
+		{PreferenceShardsPrefix + "1|", false},
+		{PreferenceShardsPrefix + "1|_unknown", false},
+		{PreferenceOnlyNodesPrefix, false},
+		{"_unknown", false},
+	}
+
+	for i, test := range tests {
+		svc := client.Search().Preference(test.Preference)
+		err := svc.Validate()
+		if test.Valid && err != nil {
+			t.Errorf("case #%d: expected preference %q to be valid; got: %v", i+1, test.Preference, err)
+			continue
+		}
+		if !test.Valid {
+			if err == nil {
+				t.Errorf("case #%d: expected preference %q to be invalid", i+1, test.Preference)
+			}
+			continue
+		}
+		_, params, err := svc.buildURL()
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if want, have := test.Preference, params.Get("preference"); want != have {
+			t.Errorf("case #%d: expected preference %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
 func TestSearchFilterPath(t *testing.T) {
 	// client := setupTestClientAndCreateIndexAndAddDocs(t, SetTraceLog(log.New(os.Stdout, "", log.LstdFlags)))
 	client := setupTestClientAndCreateIndexAndAddDocs(t)
@@ -1213,6 +1316,176 @@ func TestSearchAfter(t *testing.T) {
 	}
 }
 
+func TestSearchAfterValuesValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	// Matching number of sort values
+	err := client.Search(testIndexName).
+		SortBy(NewFieldSort("user"), NewFieldSort("retweets").Desc()).
+		SearchAfterValues("olivere", 108).
+		Validate()
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+
+	// Too few sort values
+	err = client.Search(testIndexName).
+		SortBy(NewFieldSort("user"), NewFieldSort("retweets").Desc()).
+		SearchAfterValues("olivere").
+		Validate()
+	if err == nil {
+		t.Fatal("expected error for mismatching number of search_after values")
+	}
+
+	// Too many sort values
+	err = client.Search(testIndexName).
+		Sort("user", true).
+		SearchAfterValues("olivere", 108).
+		Validate()
+	if err == nil {
+		t.Fatal("expected error for mismatching number of search_after values")
+	}
+
+	// SearchAfter checks as well
+	err = client.Search(testIndexName).
+		Sort("user", true).
+		SearchAfter("olivere", 108).
+		Validate()
+	if err == nil {
+		t.Fatal("expected error for mismatching number of search_after values")
+	}
+
+	// Point in time searches return an additional tiebreaker
+	err = client.Search().
+		PointInTime("pit-1", "1m").
+		Sort("user", true).
+		SearchAfter("olivere", 42).
+		Validate()
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+
+	// Unless the body is set explicitly
+	err = client.Search(testIndexName).
+		Source(`{"sort":["user"]}`).
+		SearchAfter("olivere", 108).
+		Validate()
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+}
+
+func TestSearchAfterCursorFromHit(t *testing.T) {
+	client := setupTestClient(t)
+
+	var res SearchResult
+	body := `{"hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1","sort":["olivere",108]},{"_index":"elastic-test","_type":"doc","_id":"2","sort":["sandrae",12]}]}}`
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	cursor := NewSearchAfterFromHit(res.Hits.Hits[len(res.Hits.Hits)-1])
+
+	svc := client.Search(testIndexName).
+		Query(NewMatchAllQuery()).
+		SortBy(NewFieldSort("user"), NewFieldSort("retweets").Desc()).
+		SearchAfterCursor(cursor)
+	if err := svc.Validate(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	src, err := svc.searchSource.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"match_all":{}},"search_after":["sandrae",12],"sort":[{"user":{"order":"asc"}},{"retweets":{"order":"desc"}}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// The cursor must match the sort clauses
+	err = client.Search(testIndexName).
+		Sort("user", true).
+		SearchAfterCursor(cursor).
+		Validate()
+	if err == nil {
+		t.Fatal("expected error for mismatching number of search_after values")
+	}
+
+	// A cursor from a nil hit has no values
+	if values := NewSearchAfterFromHit(nil).Values(); len(values) != 0 {
+		t.Fatalf("expected no values; got: %v", values)
+	}
+}
+
+func TestSearchAfterCursorPaging(t *testing.T) {
+	// The server returns two pages of one hit each, then an empty page
+	var bodies []map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		bodies = append(bodies, body)
+		w.Header().Set("Content-Type", "application/json")
+		switch len(bodies) {
+		case 1:
+			fmt.Fprintln(w, `{"hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1","sort":["olivere",108]}]}}`)
+		case 2:
+			fmt.Fprintln(w, `{"hits":{"total":2,"hits":[{"_index":"elastic-test","_type":"doc","_id":"2","sort":["sandrae",12]}]}}`)
+		default:
+			fmt.Fprintln(w, `{"hits":{"total":2,"hits":[]}}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The first page starts with a cursor from a nil hit
+	var ids []string
+	cursor := NewSearchAfterFromHit(nil)
+	for i := 0; i < 5; i++ {
+		res, err := client.Search(testIndexName).
+			Query(NewMatchAllQuery()).
+			SortBy(NewFieldSort("user"), NewFieldSort("retweets").Desc()).
+			Size(1).
+			SearchAfterCursor(cursor).
+			Do(context.TODO())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(res.Hits.Hits) == 0 {
+			break
+		}
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+		cursor = NewSearchAfterFromHit(res.Hits.Hits[len(res.Hits.Hits)-1])
+	}
+
+	if want, have := []string{"1", "2"}, ids; !reflect.DeepEqual(want, have) {
+		t.Fatalf("expected ids %v; got: %v", want, have)
+	}
+	if want, have := 3, len(bodies); want != have {
+		t.Fatalf("expected %d requests; got: %d", want, have)
+	}
+	if _, found := bodies[0]["search_after"]; found {
+		t.Errorf("expected no search_after on the first page; got: %v", bodies[0]["search_after"])
+	}
+	if want, have := []interface{}{"olivere", float64(108)}, bodies[1]["search_after"]; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected search_after %v; got: %v", want, have)
+	}
+	if want, have := []interface{}{"sandrae", float64(12)}, bodies[2]["search_after"]; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected search_after %v; got: %v", want, have)
+	}
+}
+
 func TestSearchResultWithFieldCollapsing(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) // , SetTraceLog(log.New(os.Stdout, "", 0)))
 
@@ -1319,6 +1592,70 @@ func TestSearchResultWithFieldCollapsingAndInnerHits(t *testing.T) {
 	}
 }
 
+func TestSearchHitCollapsedHits(t *testing.T) {
+	body := `{
+		"hits":{
+			"total":2,
+			"hits":[{
+				"_index":"elastic-test",
+				"_type":"doc",
+				"_id":"1",
+				"fields":{"user_id":["olivere"]},
+				"inner_hits":{
+					"top_docs":{
+						"hits":{
+							"total":3,
+							"hits":[
+								{"_index":"elastic-test","_type":"doc","_id":"1"},
+								{"_index":"elastic-test","_type":"doc","_id":"2"},
+								{"_index":"elastic-test","_type":"doc","_id":"3"}
+							]
+						}
+					}
+				}
+			},{
+				"_index":"elastic-test",
+				"_type":"doc",
+				"_id":"4",
+				"fields":{"user_id":["sandrae"]}
+			}]
+		}
+	}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Hits.Hits); want != have {
+		t.Fatalf("expected %d hits; got %d", want, have)
+	}
+
+	hits, found := res.Hits.Hits[0].CollapsedHits("top_docs")
+	if !found {
+		t.Fatalf("expected inner hits %q", "top_docs")
+	}
+	if want, have := int64(3), hits.TotalHits; want != have {
+		t.Errorf("expected TotalHits = %d; got %d", want, have)
+	}
+	var ids []string
+	for _, hit := range hits.Hits {
+		ids = append(ids, hit.Id)
+	}
+	if want, have := "1,2,3", strings.Join(ids, ","); want != have {
+		t.Errorf("expected ids %q; got %q", want, have)
+	}
+
+	if _, found := res.Hits.Hits[0].CollapsedHits("no-such-group"); found {
+		t.Error("expected unknown inner hits to be not found")
+	}
+	if _, found := res.Hits.Hits[1].CollapsedHits("top_docs"); found {
+		t.Error("expected hit without inner hits to return not found")
+	}
+	var nilHit *SearchHit
+	if _, found := nilHit.CollapsedHits("top_docs"); found {
+		t.Error("expected nil hit to return not found")
+	}
+}
+
 func TestSearchScriptQuery(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
 
@@ -1392,3 +1729,195 @@ func TestSearchWithDocvalueFields(t *testing.T) {
 		}
 	}
 }
+
+func TestSearchPointInTimeOmitsIndexFromURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	path, _, err := client.Search(testIndexName).Type("doc").PointInTime("pit-1", "1m").buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_search", path; want != have {
+		t.Errorf("expected path %q; got: %q", want, have)
+	}
+}
+
+func TestSearchRoutingValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	if err := client.Search(testIndexName).Routing("user1", "user2").Validate(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if err := client.Search(testIndexName).Routing("user1", "user2,user3").Validate(); err == nil {
+		t.Fatal("expected error for routing value with comma")
+	}
+}
+
+func TestSearchRoutingIntegration(t *testing.T) {
+	client := setupTestClient(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	// Create an index with multiple shards
+	createIndex, err := client.CreateIndex(testIndexName3).Body(`{
+		"settings":{
+			"number_of_shards":3,
+			"number_of_replicas":0
+		}
+	}`).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if createIndex == nil {
+		t.Fatalf("expected result to be != nil; got: %v", createIndex)
+	}
+
+	tweet1 := tweet{User: "olivere", Message: "Welcome to Golang and Elasticsearch."}
+	tweet2 := tweet{User: "sandrae", Message: "Cycling is fun."}
+
+	_, err = client.Index().Index(testIndexName3).Type("doc").Id("1").Routing("olivere").BodyJson(&tweet1).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Index().Index(testIndexName3).Type("doc").Id("2").Routing("sandrae").BodyJson(&tweet2).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = client.Refresh(testIndexName3).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	searchResult, err := client.Search(testIndexName3).
+		Query(NewTermQuery("user", "olivere")).
+		Routing("olivere").
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if searchResult.Shards == nil {
+		t.Fatal("expected SearchResult.Shards != nil; got nil")
+	}
+	if want, have := 1, searchResult.Shards.Total; want != have {
+		t.Errorf("expected routed search to hit %d shard; got: %d", want, have)
+	}
+	if want, have := int64(1), searchResult.TotalHits(); want != have {
+		t.Errorf("expected %d hit; got: %d", want, have)
+	}
+}
+
+func TestSearchAllowPartialSearchResults(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.Search(testIndexName).AllowPartialSearchResults(false).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "false", params.Get("allow_partial_search_results"); want != have {
+		t.Errorf("expected allow_partial_search_results = %q; got: %q", want, have)
+	}
+
+	_, params, err = client.Search(testIndexName).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, found := params["allow_partial_search_results"]; found {
+		t.Errorf("expected no allow_partial_search_results parameter; got: %q", params.Get("allow_partial_search_results"))
+	}
+}
+
+func TestSearchAllowPartialSearchResultsWithShardFailure(t *testing.T) {
+	// The server simulates a search where one of two shards fails
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("allow_partial_search_results") == "false" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintln(w, `{"error":{"type":"search_phase_execution_exception","reason":"Partial shards failure"},"status":503}`)
+			return
+		}
+		fmt.Fprintln(w, `{"took":1,"timed_out":false,"_shards":{"total":2,"successful":1,"skipped":0,"failed":1},"hits":{"total":1,"hits":[{"_index":"elastic-test","_type":"doc","_id":"1"}]}}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Partial results
+	res, err := client.Search(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, res.Shards.Failed; want != have {
+		t.Errorf("expected %d failed shard; got: %d", want, have)
+	}
+	if want, have := int64(1), res.TotalHits(); want != have {
+		t.Errorf("expected %d hit; got: %d", want, have)
+	}
+
+	// All or nothing
+	res, err = client.Search(testIndexName).AllowPartialSearchResults(false).Do(context.TODO())
+	if err == nil {
+		t.Fatalf("expected error; got: %+v", res)
+	}
+	if !IsStatusCode(err, http.StatusServiceUnavailable) {
+		t.Errorf("expected status code %d; got: %v", http.StatusServiceUnavailable, err)
+	}
+	if res != nil {
+		t.Errorf("expected no result; got: %+v", res)
+	}
+}
+
+func TestSearchResultWithSkippedShards(t *testing.T) {
+	body := `{"took":3,"timed_out":false,"_shards":{"total":5,"successful":5,"skipped":3,"failed":0},"hits":{"total":0,"hits":[]}}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Shards == nil {
+		t.Fatal("expected SearchResult.Shards != nil; got nil")
+	}
+	if want, have := 5, res.Shards.Total; want != have {
+		t.Errorf("expected %d total shards; got: %d", want, have)
+	}
+	if want, have := 5, res.Shards.Successful; want != have {
+		t.Errorf("expected %d successful shards; got: %d", want, have)
+	}
+	if want, have := 3, res.Shards.Skipped; want != have {
+		t.Errorf("expected %d skipped shards; got: %d", want, have)
+	}
+	if want, have := 0, res.Shards.Failed; want != have {
+		t.Errorf("expected %d failed shards; got: %d", want, have)
+	}
+}
+
+func TestSearchHitMatchedQueries(t *testing.T) {
+	js := `{
+		"_index":"elastic-test",
+		"_type":"doc",
+		"_id":"1",
+		"_score":1.2,
+		"matched_queries":["first","second"]
+	}`
+
+	var hit SearchHit
+	if err := json.Unmarshal([]byte(js), &hit); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := []string{"first", "second"}, hit.MatchedQueries; !reflect.DeepEqual(want, have) {
+		t.Fatalf("expected MatchedQueries = %v; got: %v", want, have)
+	}
+	if !hit.HasMatchedQuery("first") {
+		t.Errorf("expected hit to match %q", "first")
+	}
+	if !hit.HasMatchedQuery("second") {
+		t.Errorf("expected hit to match %q", "second")
+	}
+	if hit.HasMatchedQuery("third") {
+		t.Errorf("expected hit to not match %q", "third")
+	}
+
+	var nilHit *SearchHit
+	if nilHit.HasMatchedQuery("first") {
+		t.Errorf("expected nil hit to not match %q", "first")
+	}
+}
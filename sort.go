@@ -4,7 +4,10 @@
 
 package elastic
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // -- Sorter --
 
@@ -105,6 +108,47 @@ func (s SortByDoc) Source() (interface{}, error) {
 	return "_doc", nil
 }
 
+// -- DocSort --
+
+// DocSort sorts by the "_doc" field, i.e. by index order. It is the most
+// efficient sort order and is recommended when scrolling over large result
+// sets, see https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-scroll.html.
+// In contrast to SortByDoc, it allows to specify the sort order.
+type DocSort struct {
+	Sorter
+	ascending bool
+}
+
+// NewDocSort creates a new DocSort.
+func NewDocSort() *DocSort {
+	return &DocSort{ascending: true}
+}
+
+// Asc sets ascending sort order.
+func (s *DocSort) Asc() *DocSort {
+	s.ascending = true
+	return s
+}
+
+// Desc sets descending sort order.
+func (s *DocSort) Desc() *DocSort {
+	s.ascending = false
+	return s
+}
+
+// Source returns the JSON-serializable data.
+func (s *DocSort) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	x := make(map[string]interface{})
+	source["_doc"] = x
+	if s.ascending {
+		x["order"] = "asc"
+	} else {
+		x["order"] = "desc"
+	}
+	return source, nil
+}
+
 // -- ScoreSort --
 
 // ScoreSort sorts by relevancy score.
@@ -159,6 +203,8 @@ type FieldSort struct {
 	missing      interface{}
 	unmappedType *string
 	sortMode     *string
+	numericType  *string
+	format       *string
 	filter       Query
 	path         *string
 	nested       *NestedSort
@@ -219,6 +265,23 @@ func (s *FieldSort) SortMode(sortMode string) *FieldSort {
 	return s
 }
 
+// NumericType casts the values of a numeric field to the given type,
+// allowing to sort consistently across indices that map the same field
+// differently. Possible values are: long, double, date, and date_nanos.
+// The numeric_type option is available from Elasticsearch 7.2.
+func (s *FieldSort) NumericType(numericType string) *FieldSort {
+	s.numericType = &numericType
+	return s
+}
+
+// Format specifies the date format to use for the sort values returned
+// when sorting by a date field, e.g. "strict_date_optional_time_nanos".
+// This allows to pass the sort values back via SearchAfter.
+func (s *FieldSort) Format(format string) *FieldSort {
+	s.format = &format
+	return s
+}
+
 // NestedFilter sets a filter that nested objects should match with
 // in order to be taken into account for sorting.
 // Deprecated: Use Filter instead.
@@ -282,6 +345,17 @@ func (s *FieldSort) Source() (interface{}, error) {
 	if s.sortMode != nil {
 		x["mode"] = *s.sortMode
 	}
+	if s.numericType != nil {
+		switch *s.numericType {
+		case "long", "double", "date", "date_nanos":
+			x["numeric_type"] = *s.numericType
+		default:
+			return nil, fmt.Errorf("FieldSort: invalid numeric_type %q; expected one of long, double, date, or date_nanos", *s.numericType)
+		}
+	}
+	if s.format != nil {
+		x["format"] = *s.format
+	}
 	if s.filter != nil {
 		src, err := s.filter.Source()
 		if err != nil {
@@ -308,16 +382,17 @@ func (s *FieldSort) Source() (interface{}, error) {
 // See https://www.elastic.co/guide/en/elasticsearch/reference/6.2/search-request-sort.html#_geo_distance_sorting.
 type GeoDistanceSort struct {
 	Sorter
-	fieldName    string
-	points       []*GeoPoint
-	geohashes    []string
-	distanceType *string
-	unit         string
-	ascending    bool
-	sortMode     *string
-	nestedFilter Query
-	nestedPath   *string
-	nestedSort   *NestedSort
+	fieldName      string
+	points         []*GeoPoint
+	geohashes      []string
+	distanceType   *string
+	unit           string
+	ascending      bool
+	sortMode       *string
+	ignoreUnmapped *bool
+	nestedFilter   Query
+	nestedPath     *string
+	nestedSort     *NestedSort
 }
 
 // NewGeoDistanceSort creates a new sorter for geo distances.
@@ -359,12 +434,15 @@ func (s *GeoDistanceSort) Point(lat, lon float64) *GeoDistanceSort {
 }
 
 // Points specifies the geo point(s) to create the range distance aggregations from.
+// If more than one point is given, the distances are combined by the
+// sort mode (min, max, or avg).
 func (s *GeoDistanceSort) Points(points ...*GeoPoint) *GeoDistanceSort {
 	s.points = append(s.points, points...)
 	return s
 }
 
-// GeoHashes specifies the geo point to create the range distance aggregations from.
+// GeoHashes specifies the geo point(s), as geohashes, to create the range
+// distance aggregations from.
 func (s *GeoDistanceSort) GeoHashes(geohashes ...string) *GeoDistanceSort {
 	s.geohashes = append(s.geohashes, geohashes...)
 	return s
@@ -399,6 +477,15 @@ func (s *GeoDistanceSort) SortMode(sortMode string) *GeoDistanceSort {
 	return s
 }
 
+// IgnoreUnmapped indicates whether the unmapped field should be treated as
+// a missing value. Setting it to true is equivalent to specifying an
+// unmapped type in a FieldSort, i.e. the sort is skipped on indices
+// that do not map the field.
+func (s *GeoDistanceSort) IgnoreUnmapped(ignoreUnmapped bool) *GeoDistanceSort {
+	s.ignoreUnmapped = &ignoreUnmapped
+	return s
+}
+
 // NestedFilter sets a filter that nested objects should match with
 // in order to be taken into account for sorting.
 func (s *GeoDistanceSort) NestedFilter(nestedFilter Query) *GeoDistanceSort {
@@ -451,6 +538,9 @@ func (s *GeoDistanceSort) Source() (interface{}, error) {
 	if s.sortMode != nil {
 		x["mode"] = *s.sortMode
 	}
+	if s.ignoreUnmapped != nil {
+		x["ignore_unmapped"] = *s.ignoreUnmapped
+	}
 	if s.nestedFilter != nil {
 		src, err := s.nestedFilter.Source()
 		if err != nil {
@@ -544,7 +634,7 @@ func (s *ScriptSort) NestedPath(nestedPath string) *ScriptSort {
 }
 
 // NestedSort is available starting with 6.1 and will replace NestedFilter
-// and NestedPath.
+// and NestedPath. If set, NestedFilter and NestedPath are ignored.
 func (s *ScriptSort) NestedSort(nestedSort *NestedSort) *ScriptSort {
 	s.nestedSort = nestedSort
 	return s
@@ -575,22 +665,24 @@ func (s *ScriptSort) Source() (interface{}, error) {
 	if s.sortMode != nil {
 		x["mode"] = *s.sortMode
 	}
-	if s.nestedFilter != nil {
-		src, err := s.nestedFilter.Source()
-		if err != nil {
-			return nil, err
-		}
-		x["nested_filter"] = src
-	}
-	if s.nestedPath != nil {
-		x["nested_path"] = *s.nestedPath
-	}
 	if s.nestedSort != nil {
+		// NestedSort replaces NestedFilter and NestedPath
 		src, err := s.nestedSort.Source()
 		if err != nil {
 			return nil, err
 		}
 		x["nested"] = src
+	} else {
+		if s.nestedFilter != nil {
+			src, err := s.nestedFilter.Source()
+			if err != nil {
+				return nil, err
+			}
+			x["nested_filter"] = src
+		}
+		if s.nestedPath != nil {
+			x["nested_path"] = *s.nestedPath
+		}
 	}
 	return source, nil
 }
@@ -606,9 +698,10 @@ func (s *ScriptSort) Source() (interface{}, error) {
 // in the other sorters.
 type NestedSort struct {
 	Sorter
-	path       string
-	filter     Query
-	nestedSort *NestedSort
+	path        string
+	filter      Query
+	maxChildren *int
+	nestedSort  *NestedSort
 }
 
 // NewNestedSort creates a new NestedSort.
@@ -622,12 +715,26 @@ func (s *NestedSort) Filter(filter Query) *NestedSort {
 	return s
 }
 
+// MaxChildren sets the maximum number of children to consider per root
+// document when picking the sort value. It defaults to unlimited.
+func (s *NestedSort) MaxChildren(maxChildren int) *NestedSort {
+	s.maxChildren = &maxChildren
+	return s
+}
+
 // NestedSort embeds another level of nested sorting.
 func (s *NestedSort) NestedSort(nestedSort *NestedSort) *NestedSort {
 	s.nestedSort = nestedSort
 	return s
 }
 
+// Nested embeds another level of nested sorting. It is an alias for
+// NestedSort and allows chaining nested objects inside nested objects.
+func (s *NestedSort) Nested(nested *NestedSort) *NestedSort {
+	s.nestedSort = nested
+	return s
+}
+
 // Source returns the JSON-serializable data.
 func (s *NestedSort) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -642,6 +749,9 @@ func (s *NestedSort) Source() (interface{}, error) {
 		}
 		source["filter"] = src
 	}
+	if s.maxChildren != nil {
+		source["max_children"] = *s.maxChildren
+	}
 	if s.nestedSort != nil {
 		src, err := s.nestedSort.Source()
 		if err != nil {
@@ -50,6 +50,40 @@ func TestSortInfoComplex(t *testing.T) {
 	}
 }
 
+func TestDocSort(t *testing.T) {
+	builder := NewDocSort()
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_doc":{"order":"asc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestDocSortOrderDesc(t *testing.T) {
+	builder := NewDocSort().Desc()
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_doc":{"order":"desc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestScoreSort(t *testing.T) {
 	builder := NewScoreSort()
 	if builder.ascending != false {
@@ -160,6 +194,47 @@ func TestFieldSortComplex(t *testing.T) {
 	}
 }
 
+func TestFieldSortWithNumericType(t *testing.T) {
+	builder := NewFieldSort("timestamp").NumericType("date_nanos")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"timestamp":{"numeric_type":"date_nanos","order":"asc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestFieldSortWithInvalidNumericType(t *testing.T) {
+	builder := NewFieldSort("timestamp").NumericType("float")
+	if _, err := builder.Source(); err == nil {
+		t.Fatal("expected error for invalid numeric_type")
+	}
+}
+
+func TestFieldSortWithFormat(t *testing.T) {
+	builder := NewFieldSort("created").Desc().Format("strict_date_optional_time_nanos")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"created":{"format":"strict_date_optional_time_nanos","order":"desc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestGeoDistanceSort(t *testing.T) {
 	builder := NewGeoDistanceSort("pin.location").
 		Point(-70, 40).
@@ -203,6 +278,46 @@ func TestGeoDistanceSortOrderDesc(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoDistanceSortWithMultiplePoints(t *testing.T) {
+	builder := NewGeoDistanceSort("pin.location").
+		Points(GeoPointFromLatLon(-70, 40), GeoPointFromLatLon(-71, 41)).
+		GeoHashes("drm3btev3e86").
+		SortMode("avg")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_geo_distance":{"mode":"avg","order":"asc","pin.location":[{"lat":-70,"lon":40},{"lat":-71,"lon":41},"drm3btev3e86"]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoDistanceSortWithIgnoreUnmapped(t *testing.T) {
+	builder := NewGeoDistanceSort("pin.location").
+		Point(-70, 40).
+		IgnoreUnmapped(true)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_geo_distance":{"ignore_unmapped":true,"order":"asc","pin.location":[{"lat":-70,"lon":40}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestScriptSort(t *testing.T) {
 	builder := NewScriptSort(NewScript("doc['field_name'].value * factor").Param("factor", 1.1), "number").Order(true)
 	src, err := builder.Source()
@@ -237,6 +352,64 @@ func TestScriptSortOrderDesc(t *testing.T) {
 	}
 }
 
+func TestScriptSortWithNestedSort(t *testing.T) {
+	builder := NewScriptSort(NewScript("doc['offer.price'].value * params.factor").Param("factor", 1.1), "number").
+		Desc().
+		NestedSort(NewNestedSort("offer").Filter(NewTermQuery("offer.color", "blue")))
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_script":{"nested":{"filter":{"term":{"offer.color":"blue"}},"path":"offer"},"order":"desc","script":{"params":{"factor":1.1},"source":"doc['offer.price'].value * params.factor"},"type":"number"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestScriptSortNestedSortTakesPrecedence(t *testing.T) {
+	builder := NewScriptSort(NewScript("doc['offer.price'].value"), "number").
+		NestedPath("offer").
+		NestedFilter(NewTermQuery("offer.color", "red")).
+		NestedSort(NewNestedSort("offer").Filter(NewTermQuery("offer.color", "blue")))
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_script":{"nested":{"filter":{"term":{"offer.color":"blue"}},"path":"offer"},"order":"asc","script":{"source":"doc['offer.price'].value"},"type":"number"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestScriptSortWithNestedPathAndFilter(t *testing.T) {
+	builder := NewScriptSort(NewScript("doc['offer.price'].value"), "number").
+		NestedPath("offer").
+		NestedFilter(NewTermQuery("offer.color", "red"))
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_script":{"nested_filter":{"term":{"offer.color":"red"}},"nested_path":"offer","order":"asc","script":{"source":"doc['offer.price'].value"},"type":"number"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestNestedSort(t *testing.T) {
 	builder := NewNestedSort("offer").
 		Filter(NewTermQuery("offer.color", "blue"))
@@ -276,3 +449,54 @@ func TestFieldSortWithNestedSort(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestFieldSortWithNestedSortAndMaxChildren(t *testing.T) {
+	builder := NewFieldSort("offer.price").
+		Asc().
+		NestedSort(
+			NewNestedSort("offer").MaxChildren(3),
+		)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"offer.price":{"nested":{"max_children":3,"path":"offer"},"order":"asc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestFieldSortWithNestedSortChain(t *testing.T) {
+	builder := NewFieldSort("books.chapters.pages.number").
+		Asc().
+		Nested(
+			NewNestedSort("books").
+				Filter(NewTermQuery("books.genre", "fantasy")).
+				Nested(
+					NewNestedSort("books.chapters").
+						Filter(NewRangeQuery("books.chapters.length").Gte(10)).
+						Nested(
+							NewNestedSort("books.chapters.pages").
+								Filter(NewTermQuery("books.chapters.pages.illustrated", true)),
+						),
+				),
+		)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"books.chapters.pages.number":{"nested":{"filter":{"term":{"books.genre":"fantasy"}},"nested":{"filter":{"range":{"books.chapters.length":{"from":10,"include_lower":true,"include_upper":true,"to":null}}},"nested":{"filter":{"term":{"books.chapters.pages.illustrated":true}},"path":"books.chapters.pages"},"path":"books.chapters"},"path":"books"},"order":"asc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/olivere/elastic/uritemplates"
 )
@@ -38,6 +39,9 @@ type UpdateService struct {
 	doc                 interface{}
 	timeout             string
 	pretty              bool
+
+	conflictBackoff    Backoff
+	conflictMaxRetries int
 }
 
 // NewUpdateService creates the service to update documents in Elasticsearch.
@@ -193,6 +197,20 @@ func (s *UpdateService) FetchSourceContext(fetchSourceContext *FetchSourceContex
 	return s
 }
 
+// WithConflictBackoff re-issues the update up to maxRetries times when
+// Elasticsearch reports a version conflict, waiting between retries as
+// specified by backoff. If a version has been set via Version, the update
+// is not retried and the version conflict is returned to the caller.
+//
+// Unlike RetryOnConflict, which retries on the server without delay,
+// WithConflictBackoff retries from the client and allows the cluster to
+// settle down between retries.
+func (b *UpdateService) WithConflictBackoff(backoff Backoff, maxRetries int) *UpdateService {
+	b.conflictBackoff = backoff
+	b.conflictMaxRetries = maxRetries
+	return b
+}
+
 // url returns the URL part of the document request.
 func (b *UpdateService) url() (string, url.Values, error) {
 	// Build url
@@ -284,6 +302,32 @@ func (b *UpdateService) body() (interface{}, error) {
 
 // Do executes the update operation.
 func (b *UpdateService) Do(ctx context.Context) (*UpdateResponse, error) {
+	if b.conflictBackoff == nil || b.conflictMaxRetries <= 0 {
+		return b.do(ctx)
+	}
+	for retry := 1; ; retry++ {
+		res, err := b.do(ctx)
+		if err == nil || !IsConflict(err) || retry > b.conflictMaxRetries {
+			return res, err
+		}
+		if b.version != nil {
+			// The caller asked for optimistic concurrency control
+			return nil, err
+		}
+		wait, goahead := b.conflictBackoff.Next(retry)
+		if !goahead {
+			return nil, err
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// do executes a single update request.
+func (b *UpdateService) do(ctx context.Context) (*UpdateResponse, error) {
 	path, params, err := b.url()
 	if err != nil {
 		return nil, err
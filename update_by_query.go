@@ -149,7 +149,9 @@ func (s *UpdateByQueryService) Analyzer(analyzer string) *UpdateByQueryService {
 }
 
 // Conflicts indicates what to do when the process detects version conflicts.
-// Possible values are "proceed" and "abort".
+// Possible values are "proceed" and "abort". With "proceed", conflicting
+// documents are counted in BulkIndexByScrollResponse.VersionConflicts
+// instead of aborting the whole request.
 func (s *UpdateByQueryService) Conflicts(conflicts string) *UpdateByQueryService {
 	s.conflicts = conflicts
 	return s
@@ -162,7 +164,7 @@ func (s *UpdateByQueryService) AbortOnVersionConflict() *UpdateByQueryService {
 	return s
 }
 
-// ProceedOnVersionConflict aborts the request on version conflicts.
+// ProceedOnVersionConflict proceeds with the request on version conflicts.
 // It is an alias to setting Conflicts("proceed").
 func (s *UpdateByQueryService) ProceedOnVersionConflict() *UpdateByQueryService {
 	s.conflicts = "proceed"
@@ -607,6 +609,11 @@ func (s *UpdateByQueryService) Validate() error {
 	if len(invalid) > 0 {
 		return fmt.Errorf("missing required fields: %v", invalid)
 	}
+	switch s.conflicts {
+	case "", "abort", "proceed":
+	default:
+		return fmt.Errorf("conflicts must be either %q or %q; got: %q", "abort", "proceed", s.conflicts)
+	}
 	return nil
 }
 
@@ -0,0 +1,96 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUpdateByQueryRethrottleBuildURL(t *testing.T) {
+	client := setupTestClient(t)
+
+	tests := []struct {
+		TaskId            string
+		RequestsPerSecond float64
+		ExpectedPath      string
+		ExpectedRPS       string
+	}{
+		{
+			"abc:42",
+			10,
+			"/_update_by_query/abc%3A42/_rethrottle",
+			"10",
+		},
+		{
+			"abc:42",
+			-1,
+			"/_update_by_query/abc%3A42/_rethrottle",
+			"-1",
+		},
+		{
+			"abc:42",
+			0.5,
+			"/_update_by_query/abc%3A42/_rethrottle",
+			"0.5",
+		},
+	}
+
+	for i, test := range tests {
+		path, params, err := client.UpdateByQueryRethrottle(test.TaskId).RequestsPerSecond(test.RequestsPerSecond).buildURL()
+		if err != nil {
+			t.Errorf("case #%d: %v", i+1, err)
+			continue
+		}
+		if path != test.ExpectedPath {
+			t.Errorf("case #%d: expected %q; got: %q", i+1, test.ExpectedPath, path)
+		}
+		if want, have := test.ExpectedRPS, params.Get("requests_per_second"); want != have {
+			t.Errorf("case #%d: expected requests_per_second = %q; got: %q", i+1, want, have)
+		}
+	}
+}
+
+func TestUpdateByQueryRethrottleValidate(t *testing.T) {
+	client := setupTestClient(t)
+
+	if err := client.UpdateByQueryRethrottle("").RequestsPerSecond(1).Validate(); err == nil {
+		t.Error("expected Validate to fail without TaskId")
+	}
+	if err := client.UpdateByQueryRethrottle("abc:42").Validate(); err == nil {
+		t.Error("expected Validate to fail without RequestsPerSecond")
+	}
+	if err := client.UpdateByQueryRethrottle("abc:42").RequestsPerSecond(-1).Validate(); err != nil {
+		t.Errorf("expected Validate to succeed; got: %v", err)
+	}
+}
+
+func TestUpdateByQueryRethrottle(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	// Start a slow update-by-query: one document per batch, one batch per second
+	task, err := client.UpdateByQuery(testIndexName).
+		ProceedOnVersionConflict().
+		ScrollSize(1).
+		RequestsPerSecond(1).
+		DoAsync(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task == nil || task.TaskId == "" {
+		t.Fatalf("expected a task id; got %+v", task)
+	}
+
+	res, err := client.UpdateByQueryRethrottle(task.TaskId).RequestsPerSecond(100).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("expected response != nil")
+	}
+	if want, have := float64(100), rethrottledRequestsPerSecond(t, res); want != have {
+		t.Fatalf("expected requests_per_second = %v; got: %v", want, have)
+	}
+}
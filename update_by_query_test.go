@@ -7,6 +7,9 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -116,6 +119,74 @@ func TestUpdateByQueryBodyWithQueryAndScript(t *testing.T) {
 	}
 }
 
+func TestUpdateByQueryBuildURLWithPipeline(t *testing.T) {
+	client := setupTestClient(t)
+
+	_, params, err := client.UpdateByQuery(testIndexName).Pipeline("my-pipeline").buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "my-pipeline", params.Get("pipeline"); want != have {
+		t.Errorf("expected pipeline = %q; got: %q", want, have)
+	}
+}
+
+func TestUpdateByQueryConflictsAndScrollSize(t *testing.T) {
+	client := setupTestClient(t)
+
+	svc := client.UpdateByQuery(testIndexName).Conflicts("proceed").ScrollSize(500)
+	if err := svc.Validate(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	_, params, err := svc.buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "proceed", params.Get("conflicts"); want != have {
+		t.Errorf("expected conflicts = %q; got: %q", want, have)
+	}
+	if want, have := "500", params.Get("scroll_size"); want != have {
+		t.Errorf("expected scroll_size = %q; got: %q", want, have)
+	}
+
+	if err := client.UpdateByQuery(testIndexName).Conflicts("abort").Validate(); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if err := client.UpdateByQuery(testIndexName).Conflicts("ignore").Validate(); err == nil {
+		t.Fatal("expected error for invalid conflicts value")
+	}
+}
+
+func TestUpdateByQueryResponseWithVersionConflictsAndNoops(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if want, have := "proceed", r.URL.Query().Get("conflicts"); want != have {
+			t.Errorf("expected conflicts = %q; got: %q", want, have)
+		}
+		fmt.Fprintln(w, `{"took":147,"timed_out":false,"total":120,"updated":100,"deleted":0,"batches":1,"version_conflicts":12,"noops":8,"retries":{"bulk":0,"search":0},"throttled_millis":0,"requests_per_second":-1.0,"throttled_until_millis":0,"failures":[]}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.UpdateByQuery(testIndexName).ProceedOnVersionConflict().Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(100), res.Updated; want != have {
+		t.Errorf("expected updated = %d; got: %d", want, have)
+	}
+	if want, have := int64(12), res.VersionConflicts; want != have {
+		t.Errorf("expected version_conflicts = %d; got: %d", want, have)
+	}
+	if want, have := int64(8), res.Noops; want != have {
+		t.Errorf("expected noops = %d; got: %d", want, have)
+	}
+}
+
 func TestUpdateByQuery(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
 	esversion, err := client.ElasticsearchVersion(DefaultURL)
@@ -146,6 +217,56 @@ func TestUpdateByQuery(t *testing.T) {
 	}
 }
 
+func TestUpdateByQueryWithPipeline(t *testing.T) {
+	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
+
+	pipelineDef := `{
+  "description" : "mark as updated",
+  "processors" : [
+    {
+      "set" : {
+        "field": "pipeline_updated",
+        "value": true
+      }
+    }
+  ]
+}`
+	_, err := client.IngestPutPipeline("elastic-test-update-by-query").BodyString(pipelineDef).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.IngestDeletePipeline("elastic-test-update-by-query").Do(context.TODO())
+
+	sourceCount, err := client.Count(testIndexName).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.UpdateByQuery(testIndexName).
+		Pipeline("elastic-test-update-by-query").
+		ProceedOnVersionConflict().
+		Refresh("true").
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res == nil {
+		t.Fatal("response is nil")
+	}
+	if res.Updated != sourceCount {
+		t.Fatalf("expected %d; got: %d", sourceCount, res.Updated)
+	}
+
+	// All documents must have passed through the pipeline
+	updatedCount, err := client.Count(testIndexName).Query(NewTermQuery("pipeline_updated", true)).Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := sourceCount, updatedCount; want != have {
+		t.Fatalf("expected %d documents with pipeline field; got: %d", want, have)
+	}
+}
+
 func TestUpdateByQueryAsync(t *testing.T) {
 	client := setupTestClientAndCreateIndexAndAddDocs(t) //, SetTraceLog(log.New(os.Stdout, "", 0)))
 	esversion, err := client.ElasticsearchVersion(DefaultURL)
@@ -7,8 +7,14 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"reflect"
+	"sync/atomic"
 	"testing"
+	"time"
 )
 
 func TestUpdateViaScript(t *testing.T) {
@@ -260,3 +266,107 @@ func TestUpdateAndFetchSource(t *testing.T) {
 		t.Errorf("expected\n%s\ngot:\n%s", expected, got)
 	}
 }
+
+func TestUpdateWithConflictBackoff(t *testing.T) {
+	var updates int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&updates, 1) <= 2 {
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintln(w, `{"error":{"type":"version_conflict_engine_exception"},"status":409}`)
+			return
+		}
+		fmt.Fprintln(w, `{"_index":"elastic-test","_type":"doc","_id":"1","_version":4,"result":"updated"}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := client.Update().Index(testIndexName).Type("doc").Id("1").
+		Doc(map[string]interface{}{"retweets": 42}).
+		WithConflictBackoff(NewConstantBackoff(time.Millisecond), 3).
+		Do(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(4), res.Version; want != have {
+		t.Errorf("expected version %d; got: %d", want, have)
+	}
+	if want, have := int32(3), atomic.LoadInt32(&updates); want != have {
+		t.Errorf("expected %d update requests; got: %d", want, have)
+	}
+}
+
+func TestUpdateWithConflictBackoffGivesUp(t *testing.T) {
+	var updates int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		atomic.AddInt32(&updates, 1)
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintln(w, `{"error":{"type":"version_conflict_engine_exception"},"status":409}`)
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = client.Update().Index(testIndexName).Type("doc").Id("1").
+		Doc(map[string]interface{}{"retweets": 42}).
+		WithConflictBackoff(NewConstantBackoff(time.Millisecond), 2).
+		Do(context.TODO())
+	if !IsConflict(err) {
+		t.Fatalf("expected conflict error; got: %v", err)
+	}
+	if want, have := int32(3), atomic.LoadInt32(&updates); want != have {
+		t.Errorf("expected %d update requests; got: %d", want, have)
+	}
+}
+
+func TestUpdateWithConflictBackoffAndVersion(t *testing.T) {
+	var (
+		gets     int32
+		versions []string
+	)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case "GET":
+			atomic.AddInt32(&gets, 1)
+			fmt.Fprintln(w, `{"_index":"elastic-test","_type":"doc","_id":"1","_version":7,"found":true}`)
+		case "POST":
+			versions = append(versions, r.URL.Query().Get("version"))
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintln(w, `{"error":{"type":"version_conflict_engine_exception"},"status":409}`)
+		}
+	}))
+	defer ts.Close()
+
+	client, err := NewSimpleClient(SetURL(ts.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// With an explicit version, the conflict is returned without retrying
+	svc := client.Update().Index(testIndexName).Type("doc").Id("1").
+		Doc(map[string]interface{}{"retweets": 42}).
+		Version(5).
+		WithConflictBackoff(NewConstantBackoff(time.Millisecond), 3)
+	_, err = svc.Do(context.TODO())
+	if !IsConflict(err) {
+		t.Fatalf("expected conflict error; got: %v", err)
+	}
+	if want, have := []string{"5"}, versions; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected update requests with versions %v; got: %v", want, have)
+	}
+	if want, have := int32(0), atomic.LoadInt32(&gets); want != have {
+		t.Errorf("expected %d get requests; got: %d", want, have)
+	}
+	if svc.version == nil || *svc.version != 5 {
+		t.Errorf("expected version to remain 5; got: %v", svc.version)
+	}
+}